@@ -0,0 +1,284 @@
+package analyze
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"security_assistant/internal/typeresolve"
+)
+
+// Finding is a single taint-flow diagnostic: a tainted value reaching a
+// sink without passing through a recognized sanitizer.
+type Finding struct {
+	Class    Class
+	CWE      string
+	Severity string
+	Message  string
+	File     string
+	Line     int
+	Column   int
+}
+
+// Run analyzes the Go source file or directory at path and returns every
+// taint flow it finds from a source to a sink. Directories are walked
+// recursively; non-Go files are skipped.
+func Run(path string) ([]Finding, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	if info.IsDir() {
+		err = filepath.Walk(path, func(p string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !fi.IsDir() && strings.HasSuffix(p, ".go") {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		files = []string{path}
+	}
+
+	var findings []Finding
+	fset := token.NewFileSet()
+	resolver := typeresolve.NewResolver(fset)
+	for _, f := range files {
+		src, err := parser.ParseFile(fset, f, nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("analyze: parsing %s: %w", f, err)
+		}
+		typeInfo := resolver.Check(fset, src)
+		findings = append(findings, analyzeFile(fset, f, src, typeInfo)...)
+	}
+	return findings, nil
+}
+
+// analyzeFile runs the taint analysis over every function declared in src.
+func analyzeFile(fset *token.FileSet, filename string, src *ast.File, typeInfo *typeresolve.Info) []Finding {
+	var findings []Finding
+	for _, decl := range src.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		findings = append(findings, analyzeFunc(fset, filename, fn, typeInfo)...)
+	}
+	return findings
+}
+
+func analyzeFunc(fset *token.FileSet, filename string, fn *ast.FuncDecl, typeInfo *typeresolve.Info) []Finding {
+	scope := newTaintScope()
+	var findings []Finding
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.AssignStmt:
+			handleAssign(scope, v)
+		case *ast.IfStmt:
+			handleGuardClause(scope, v)
+		case *ast.CallExpr:
+			if f := checkSink(scope, fset, filename, v, typeInfo); f != nil {
+				findings = append(findings, *f)
+			}
+		}
+		return true
+	})
+	return findings
+}
+
+// handleGuardClause recognizes the `if !sanitizer(x) { return ... }` idiom
+// and clears taint on x for the remainder of the function, the same way a
+// reassignment through a sanitizer already does in handleAssign. Without
+// this, code that validates its input and bails out early (rather than
+// reassigning the validated value to a new variable) is still flagged as
+// if the validation never happened.
+func handleGuardClause(scope *taintScope, ifStmt *ast.IfStmt) {
+	if ifStmt.Init != nil || ifStmt.Else != nil {
+		return
+	}
+	unary, ok := ifStmt.Cond.(*ast.UnaryExpr)
+	if !ok || unary.Op != token.NOT {
+		return
+	}
+	call, ok := unary.X.(*ast.CallExpr)
+	if !ok || !sanitizerCall(call) {
+		return
+	}
+	if len(ifStmt.Body.List) == 0 {
+		return
+	}
+	if _, ok := ifStmt.Body.List[len(ifStmt.Body.List)-1].(*ast.ReturnStmt); !ok {
+		return
+	}
+	for _, arg := range call.Args {
+		if ident, ok := arg.(*ast.Ident); ok {
+			scope.clear(ident.Name)
+		}
+	}
+}
+
+// handleAssign propagates or clears taint across an assignment, covering
+// plain assignment, string concatenation, and fmt.Sprintf-style
+// construction of new tainted values.
+func handleAssign(scope *taintScope, assign *ast.AssignStmt) {
+	for i, lhs := range assign.Lhs {
+		ident, ok := lhs.(*ast.Ident)
+		if !ok || ident.Name == "_" {
+			continue
+		}
+		if i >= len(assign.Rhs) {
+			continue
+		}
+		rhs := assign.Rhs[i]
+		if call, ok := rhs.(*ast.CallExpr); ok && sanitizerCall(call) {
+			scope.clear(ident.Name)
+			continue
+		}
+		if scope.exprTainted(rhs) {
+			scope.mark(ident.Name)
+		} else {
+			scope.clear(ident.Name)
+		}
+	}
+}
+
+// sink describes a call site whose tainted arguments indicate a
+// vulnerability of the given class. A sink matches on selector name
+// alone only as a fallback; when type information is available, it must
+// also resolve to one of pkgPaths (for package-level functions, e.g.
+// "os/exec".Command) or recvTypes (for methods, e.g. "database/sql".DB),
+// so an unrelated type with a same-named method isn't flagged.
+type sink struct {
+	class     Class
+	selector  string   // method/function name to match, e.g. "Query", "Command"
+	argIdx    int      // which argument to inspect for taint; -1 means "any"
+	pkgPaths  []string // acceptable import paths for a package-level function sink
+	recvTypes []string // acceptable fully-qualified receiver types for a method sink
+}
+
+var sinks = []sink{
+	{class: ClassSQLInjection, selector: "Query", argIdx: 0, recvTypes: []string{"database/sql.DB", "database/sql.Tx", "database/sql.Conn"}},
+	{class: ClassSQLInjection, selector: "Exec", argIdx: 0, recvTypes: []string{"database/sql.DB", "database/sql.Tx", "database/sql.Conn"}},
+	{class: ClassSQLInjection, selector: "QueryRow", argIdx: 0, recvTypes: []string{"database/sql.DB", "database/sql.Tx", "database/sql.Conn"}},
+	{class: ClassCommandInjection, selector: "Command", argIdx: -1, pkgPaths: []string{"os/exec"}},
+	{class: ClassPathTraversal, selector: "ReadFile", argIdx: 0, pkgPaths: []string{"io/ioutil", "os"}},
+	{class: ClassPathTraversal, selector: "Open", argIdx: 0, pkgPaths: []string{"os"}},
+	{class: ClassXSS, selector: "Fprintf", argIdx: -1, pkgPaths: []string{"fmt"}},
+}
+
+func checkSink(scope *taintScope, fset *token.FileSet, filename string, call *ast.CallExpr, typeInfo *typeresolve.Info) *Finding {
+	name := calleeName(call)
+	sel, _ := call.Fun.(*ast.SelectorExpr)
+	for _, sk := range sinks {
+		if name != sk.selector {
+			continue
+		}
+		if sel != nil && !sinkReceiverMatches(sel, sk, typeInfo) {
+			continue
+		}
+		if sk.class == ClassXSS && !fprintfHasFormatSpecifier(call) {
+			continue
+		}
+		if !sinkArgsTainted(scope, call, sk.argIdx) {
+			continue
+		}
+		pos := fset.Position(call.Pos())
+		return &Finding{
+			Class:    sk.class,
+			CWE:      cwe[sk.class],
+			Severity: severityFor(sk.class),
+			Message:  fmt.Sprintf("tainted value reaches %s sink %q without sanitization", sk.class, name),
+			File:     filename,
+			Line:     pos.Line,
+			Column:   pos.Column,
+		}
+	}
+	return nil
+}
+
+// sinkReceiverMatches reports whether sel's actual package qualifier or
+// method receiver (as resolved by typeInfo) is one sk accepts. When
+// typeInfo can't resolve sel at all, it falls back to the bare-name match
+// checkSink already did - the same permissive behavior this package has
+// always had for files it can't fully type-check.
+func sinkReceiverMatches(sel *ast.SelectorExpr, sk sink, typeInfo *typeresolve.Info) bool {
+	if len(sk.pkgPaths) > 0 {
+		path, ok := typeInfo.PackageQualifier(sel)
+		if !ok {
+			return true
+		}
+		for _, want := range sk.pkgPaths {
+			if path == want {
+				return true
+			}
+		}
+		return false
+	}
+	if len(sk.recvTypes) > 0 {
+		recv, ok := typeInfo.ReceiverType(sel)
+		if !ok {
+			return true
+		}
+		for _, want := range sk.recvTypes {
+			if recv == want {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func calleeName(call *ast.CallExpr) string {
+	switch fn := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		return fn.Sel.Name
+	case *ast.Ident:
+		return fn.Name
+	}
+	return ""
+}
+
+// fprintfHasFormatSpecifier guards the Fprintf sink so plain,
+// format-string-only calls (no tainted interpolation at all) are ignored
+// even before taint checking runs; the real decision is still made by
+// sinkArgsTainted.
+func fprintfHasFormatSpecifier(call *ast.CallExpr) bool {
+	return len(call.Args) > 1
+}
+
+func sinkArgsTainted(scope *taintScope, call *ast.CallExpr, argIdx int) bool {
+	if argIdx >= 0 {
+		if argIdx >= len(call.Args) {
+			return false
+		}
+		return scope.exprTainted(call.Args[argIdx])
+	}
+	for _, arg := range call.Args {
+		if scope.exprTainted(arg) {
+			return true
+		}
+	}
+	return false
+}
+
+func severityFor(c Class) string {
+	switch c {
+	case ClassCommandInjection, ClassSQLInjection:
+		return "error"
+	default:
+		return "warning"
+	}
+}
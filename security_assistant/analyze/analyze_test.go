@@ -0,0 +1,141 @@
+package analyze
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunFindsEachClass(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       string
+		wantClass Class
+	}{
+		{
+			name: "sql-injection",
+			src: `package vuln
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) error {
+	username := r.FormValue("username")
+	query := "SELECT * FROM users WHERE username = '" + username + "'"
+	_, err := db.Query(query)
+	return err
+}
+`,
+			wantClass: ClassSQLInjection,
+		},
+		{
+			name: "command-injection",
+			src: `package vuln
+
+import (
+	"net/http"
+	"os/exec"
+)
+
+func handler(r *http.Request) ([]byte, error) {
+	host := r.FormValue("host")
+	cmd := exec.Command("sh", "-c", "ping "+host)
+	return cmd.Output()
+}
+`,
+			wantClass: ClassCommandInjection,
+		},
+		{
+			name: "path-traversal",
+			src: `package vuln
+
+import (
+	"io/ioutil"
+	"net/http"
+)
+
+func handler(r *http.Request) ([]byte, error) {
+	filename := r.FormValue("filename")
+	path := "/var/www/uploads/" + filename
+	return ioutil.ReadFile(path)
+}
+`,
+			wantClass: ClassPathTraversal,
+		},
+		{
+			name: "xss",
+			src: `package vuln
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	name := r.FormValue("name")
+	fmt.Fprintf(w, "<div>%s</div>", name)
+}
+`,
+			wantClass: ClassXSS,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "vuln.go")
+			if err := os.WriteFile(path, []byte(tt.src), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			findings, err := Run(path)
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			for _, f := range findings {
+				if f.Class == tt.wantClass {
+					return
+				}
+			}
+			t.Errorf("Run(%s) did not report a %s finding; got %+v", tt.name, tt.wantClass, findings)
+		})
+	}
+}
+
+// TestRunRecognizesGuardClause is the guard-clause regression case: a
+// `if !sanitizer(x) { return }` early-exit clears taint on x, matching
+// the project's own canonical secure command-injection example, which
+// validates with regexp.MatchString before calling exec.Command directly.
+func TestRunRecognizesGuardClause(t *testing.T) {
+	src := `package secure
+
+import (
+	"errors"
+	"net/http"
+	"os/exec"
+	"regexp"
+)
+
+func handler(r *http.Request) ([]byte, error) {
+	host := r.FormValue("host")
+	if !regexp.MustCompile(` + "`^[a-zA-Z0-9.-]+$`" + `).MatchString(host) {
+		return nil, errors.New("invalid hostname")
+	}
+	cmd := exec.Command("ping", "-c", "4", host)
+	return cmd.Output()
+}
+`
+	path := filepath.Join(t.TempDir(), "secure.go")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	findings, err := Run(path)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	for _, f := range findings {
+		if f.Class == ClassCommandInjection {
+			t.Errorf("Run flagged input cleared by a guard clause: %+v", f)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package analyze
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// SARIF types below implement the subset of the SARIF 2.1.0 schema
+// (https://docs.oasis-open.org/sarif/sarif/v2.1.0) needed to report
+// Finding values in a form CI tooling can consume.
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string              `json:"id"`
+	Name             string              `json:"name"`
+	ShortDescription sarifText           `json:"shortDescription"`
+	Properties       sarifRuleProperties `json:"properties,omitempty"`
+}
+
+type sarifRuleProperties struct {
+	Tags []string `json:"tags,omitempty"`
+}
+
+type sarifText struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifText       `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+// WriteSARIF encodes findings as a SARIF 2.1.0 log and writes it to w.
+func WriteSARIF(w io.Writer, findings []Finding) error {
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:  "secassist-analyze",
+						Rules: sarifRules(),
+					},
+				},
+				Results: sarifResults(findings),
+			},
+		},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+func sarifRules() []sarifRule {
+	classes := []Class{ClassSQLInjection, ClassCommandInjection, ClassPathTraversal, ClassXSS}
+	rules := make([]sarifRule, 0, len(classes))
+	for _, c := range classes {
+		rules = append(rules, sarifRule{
+			ID:               string(c),
+			Name:             string(c),
+			ShortDescription: sarifText{Text: "Tainted value reaches a " + string(c) + " sink"},
+			Properties:       sarifRuleProperties{Tags: []string{cwe[c]}},
+		})
+	}
+	return rules
+}
+
+func sarifResults(findings []Finding) []sarifResult {
+	results := make([]sarifResult, 0, len(findings))
+	for _, f := range findings {
+		results = append(results, sarifResult{
+			RuleID:  string(f.Class),
+			Level:   f.Severity,
+			Message: sarifText{Text: f.Message},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: f.File},
+						Region: sarifRegion{
+							StartLine:   f.Line,
+							StartColumn: f.Column,
+						},
+					},
+				},
+			},
+		})
+	}
+	return results
+}
@@ -0,0 +1,129 @@
+// Package analyze implements a lightweight intraprocedural taint analysis
+// over Go source, built on go/parser and go/ast. It recognizes the four
+// vulnerability classes documented as code examples in
+// security_assistant/remediation/code_examples: SQL injection, command
+// injection, path traversal, and XSS.
+package analyze
+
+import (
+	"go/ast"
+)
+
+// Class identifies which vulnerability family a Finding belongs to.
+type Class string
+
+const (
+	ClassSQLInjection     Class = "sql-injection"
+	ClassCommandInjection Class = "command-injection"
+	ClassPathTraversal    Class = "path-traversal"
+	ClassXSS              Class = "xss"
+)
+
+// cwe maps each Class to the CWE identifier used in remediation/code_examples.
+var cwe = map[Class]string{
+	ClassSQLInjection:     "CWE-89",
+	ClassCommandInjection: "CWE-78",
+	ClassPathTraversal:    "CWE-22",
+	ClassXSS:              "CWE-79",
+}
+
+// taintSource describes a call expression whose result should be treated as
+// attacker-controlled.
+type taintSource struct {
+	recv string // receiver type/package, e.g. "http.Request", "gin.Context", "" for bare funcs
+	name string // selector or function name, e.g. "FormValue", "Args"
+}
+
+var sources = []taintSource{
+	{recv: "Request", name: "FormValue"},
+	{recv: "Request", name: "URL"},
+	{recv: "Context", name: "Param"},
+	{recv: "Context", name: "Query"},
+	{recv: "Context", name: "PostForm"},
+	{recv: "Scanner", name: "Text"},
+	{recv: "os", name: "Args"},
+	{recv: "os", name: "Getenv"},
+}
+
+// isTaintSourceCall reports whether call matches one of the known taint
+// sources, either as pkg.Func(...) or recv.Method(...).
+func isTaintSourceCall(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	for _, s := range sources {
+		if sel.Sel.Name != s.name {
+			continue
+		}
+		if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == s.recv {
+			return true
+		}
+		// Receiver is some other expression (e.g. r.FormValue, c.Param) -
+		// match on method name alone since we don't have type info.
+		if s.recv != "os" {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizerCall reports whether call is one of the recognized sanitizers
+// from remediation/code_examples (filepath.Clean, regexp MatchString,
+// path.Base, html/template Execute).
+func sanitizerCall(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	switch sel.Sel.Name {
+	case "Clean", "Base", "MatchString", "Execute", "ExecuteTemplate":
+		return true
+	}
+	return false
+}
+
+// taintScope tracks which identifiers are currently considered tainted
+// within a single function body.
+type taintScope struct {
+	tainted map[string]bool
+}
+
+func newTaintScope() *taintScope {
+	return &taintScope{tainted: map[string]bool{}}
+}
+
+func (s *taintScope) mark(name string)  { s.tainted[name] = true }
+func (s *taintScope) clear(name string) { delete(s.tainted, name) }
+func (s *taintScope) isTainted(name string) bool {
+	return s.tainted[name]
+}
+
+// exprTainted reports whether expr references any currently-tainted
+// identifier, or is itself a taint source call.
+func (s *taintScope) exprTainted(expr ast.Expr) bool {
+	tainted := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.Ident:
+			if s.isTainted(v.Name) {
+				tainted = true
+			}
+		case *ast.SelectorExpr:
+			if pkg, ok := v.X.(*ast.Ident); ok && pkg.Name == "os" && v.Sel.Name == "Args" {
+				tainted = true
+			}
+		case *ast.CallExpr:
+			if isTaintSourceCall(v) {
+				tainted = true
+			}
+			if sanitizerCall(v) {
+				// A sanitizer call cleans its argument's contribution to
+				// this expression; stop descending into it.
+				return false
+			}
+		}
+		return true
+	})
+	return tainted
+}
@@ -0,0 +1,289 @@
+// Command secassist is the security-assistant CLI. It groups the
+// subsystems under security_assistant/ (static analysis, remediation,
+// pattern matching, safe wrappers, fuzz-harness generation) behind a
+// single entry point.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"security_assistant/analyze"
+	"security_assistant/fuzzgen"
+	"security_assistant/patterns"
+	"security_assistant/remediation/fix"
+	"security_assistant/safe/vetcheck"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "analyze":
+		if err := runAnalyze(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "secassist analyze:", err)
+			os.Exit(1)
+		}
+	case "fix":
+		if err := runFix(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "secassist fix:", err)
+			os.Exit(1)
+		}
+	case "patterns":
+		if err := runPatterns(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "secassist patterns:", err)
+			os.Exit(1)
+		}
+	case "vet":
+		if err := runVet(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "secassist vet:", err)
+			os.Exit(1)
+		}
+	case "fuzzgen":
+		if err := runFuzzgen(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "secassist fuzzgen:", err)
+			os.Exit(1)
+		}
+	case "help", "-h", "--help":
+		usage()
+	default:
+		fmt.Fprintf(os.Stderr, "secassist: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage: secassist <command> [arguments]
+
+commands:
+  analyze <path>          run taint analysis over a Go file or directory, emitting SARIF
+  fix [--write] <file>    rewrite vulnerable patterns in a Go file to their secure form
+  patterns match [--fix] <path>  scan a Go file against the pattern corpus; --fix rewrites it
+                          in place for the first finding whose pattern has auto_fix set
+  patterns verify         check every pattern against its own fixtures
+  vet <path>              flag direct unsafe stdlib calls in packages that import safe/
+  fuzzgen [flags]         generate a *_fuzz_test.go harness for a target function
+
+fuzzgen flags:
+  -import <path>          import path of the package under test
+  -func <name>            exported function name to fuzz
+  -pkg <name>             package name for the generated _fuzz_test.go (default "<import base>_test")
+  -param <name>:<kind>    a string parameter to fuzz; kind is sql, command, path, or html (repeatable)
+  -readfile               generate the path-traversal oracle harness for a safe.ReadFile-shaped
+                          func(baseDir, path string) ([]byte, error); -param is ignored`)
+}
+
+func runAnalyze(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected exactly one path argument")
+	}
+	findings, err := analyze.Run(args[0])
+	if err != nil {
+		return err
+	}
+	return analyze.WriteSARIF(os.Stdout, findings)
+}
+
+func runFix(args []string) error {
+	fs := flag.NewFlagSet("fix", flag.ExitOnError)
+	write := fs.Bool("write", false, "apply the rewrite in place instead of printing a diff")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("expected exactly one file argument")
+	}
+	filename := fs.Arg(0)
+
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	result, err := fix.Rewrite(filename, src)
+	if err != nil {
+		return err
+	}
+	if !result.Changed {
+		fmt.Fprintln(os.Stderr, "secassist fix: no known vulnerable patterns found")
+		return nil
+	}
+	if *write {
+		return os.WriteFile(filename, result.Rewritten, 0o644)
+	}
+	fmt.Print(result.Diff)
+	return nil
+}
+
+func runPatterns(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("expected a subcommand: match or verify")
+	}
+
+	corpus, err := patterns.LoadAll()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "match":
+		fs := flag.NewFlagSet("patterns match", flag.ExitOnError)
+		doFix := fs.Bool("fix", false, "rewrite the file in place for the first finding whose pattern has auto_fix set")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return fmt.Errorf("expected exactly one file argument")
+		}
+		filename := fs.Arg(0)
+
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		findings, err := patterns.Match(src, corpus)
+		if err != nil {
+			return err
+		}
+		for _, f := range findings {
+			fmt.Printf("%s:%d: [%s/%s] %s\n", filename, f.Line, f.PatternID, f.Severity, f.Message)
+		}
+		if !*doFix {
+			return nil
+		}
+		return autoFix(filename, src, findings, corpus)
+	case "verify":
+		results, err := patterns.Verify(corpus)
+		if err != nil {
+			return err
+		}
+		failed := 0
+		for _, r := range results {
+			status := "ok"
+			if !r.OK() {
+				status = "FAIL"
+				failed++
+			}
+			fmt.Printf("%-40s %s\n", r.PatternID, status)
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d pattern(s) failed verification", failed)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown patterns subcommand %q", args[0])
+	}
+}
+
+// autoFix looks up each finding's pattern and applies the first one whose
+// AutoFix is set and whose Pattern.Fix hook actually changes something,
+// writing the result back to filename.
+func autoFix(filename string, src []byte, findings []patterns.Finding, corpus []patterns.Pattern) error {
+	byID := make(map[string]patterns.Pattern, len(corpus))
+	for _, p := range corpus {
+		byID[p.ID] = p
+	}
+
+	for _, f := range findings {
+		p, known := byID[f.PatternID]
+		if !known {
+			continue
+		}
+		result, ok, err := p.Fix(filename, src)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := os.WriteFile(filename, result.Rewritten, 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "secassist patterns match: auto-fixed via %s\n", p.ID)
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, "secassist patterns match: no auto-fixable findings")
+	return nil
+}
+
+// paramFlags collects repeated -param name:kind flags into fuzzgen.Params.
+type paramFlags []fuzzgen.Param
+
+func (p *paramFlags) String() string { return fmt.Sprint([]fuzzgen.Param(*p)) }
+
+func (p *paramFlags) Set(value string) error {
+	name, kind, ok := strings.Cut(value, ":")
+	if !ok {
+		return fmt.Errorf("expected -param <name>:<kind>, got %q", value)
+	}
+	*p = append(*p, fuzzgen.Param{Name: name, Kind: fuzzgen.Kind(kind)})
+	return nil
+}
+
+func runFuzzgen(args []string) error {
+	fs := flag.NewFlagSet("fuzzgen", flag.ExitOnError)
+	importPath := fs.String("import", "", "import path of the package under test")
+	funcName := fs.String("func", "", "exported function name to fuzz")
+	pkgName := fs.String("pkg", "", `package name for the generated file (default "<import base>_test")`)
+	readfile := fs.Bool("readfile", false, "generate the ReadFile path-traversal oracle harness")
+	var params paramFlags
+	fs.Var(&params, "param", "a string parameter to fuzz, as name:kind (repeatable)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *importPath == "" || *funcName == "" {
+		return fmt.Errorf("-import and -func are required")
+	}
+	if *pkgName == "" {
+		*pkgName = fuzzgen.AliasFor(*importPath) + "_test"
+	}
+
+	var (
+		src []byte
+		err error
+	)
+	if *readfile {
+		src, err = fuzzgen.GenerateReadFileHarness(fuzzgen.ReadFileTarget{
+			Package: *pkgName,
+			Import:  *importPath,
+			Func:    *funcName,
+		})
+	} else {
+		if len(params) == 0 {
+			return fmt.Errorf("at least one -param is required unless -readfile is set")
+		}
+		src, err = fuzzgen.Generate(fuzzgen.Target{
+			Package: *pkgName,
+			Import:  *importPath,
+			Func:    *funcName,
+			Params:  params,
+		})
+	}
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(src)
+	return err
+}
+
+func runVet(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected exactly one path argument")
+	}
+	violations, err := vetcheck.Check(args[0])
+	if err != nil {
+		return err
+	}
+	for _, v := range violations {
+		fmt.Printf("%s:%d:%d: use %s instead of %s\n", v.File, v.Line, v.Column, v.Suggested, v.Call)
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("%d violation(s) found", len(violations))
+	}
+	return nil
+}
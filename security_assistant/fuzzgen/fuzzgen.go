@@ -0,0 +1,195 @@
+// Package fuzzgen generates go test fuzz harnesses
+// (func FuzzX(f *testing.F)) tailored to the four vulnerability classes
+// analyzed elsewhere in security_assistant: SQL injection, command
+// injection, path traversal, and XSS. Each generated harness seeds
+// f.Add with a corpus appropriate to its parameters' Kind; fuzzing's own
+// crash detection is the oracle for most classes, since a wrapper that
+// panics or hangs on injection payloads is itself the bug. Path
+// traversal gets an explicit oracle (see GenerateReadFileHarness)
+// because "did the read escape its base directory" can't be inferred
+// from a crash alone.
+package fuzzgen
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+//go:embed testdata/*.txt
+var corpusFS embed.FS
+
+// Kind identifies which seed corpus a fuzzed parameter should draw from.
+type Kind string
+
+const (
+	KindSQL     Kind = "sql"
+	KindCommand Kind = "command"
+	KindPath    Kind = "path"
+	KindHTML    Kind = "html"
+)
+
+var corpusFile = map[Kind]string{
+	KindSQL:     "testdata/sql.txt",
+	KindCommand: "testdata/command.txt",
+	KindPath:    "testdata/path.txt",
+	KindHTML:    "testdata/html.txt",
+}
+
+// Param describes one string parameter of the target function and which
+// vulnerability class it plays the role of tainted input for.
+type Param struct {
+	Name string
+	Kind Kind
+}
+
+// Target describes the function fuzzgen should generate a harness for.
+// All Params must currently be string-typed; Generate rejects anything
+// else since the seed corpora here are all string payloads.
+type Target struct {
+	Package string // package name of the generated _fuzz_test.go file
+	Import  string // import path of the package under test
+	Func    string // exported function name, called as <alias>.<Func>
+	Params  []Param
+}
+
+// seeds loads and splits the corpus for kind into individual payloads.
+func seeds(kind Kind) ([]string, error) {
+	path, ok := corpusFile[kind]
+	if !ok {
+		return nil, fmt.Errorf("fuzzgen: unknown kind %q", kind)
+	}
+	data, err := corpusFS.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("fuzzgen: reading %s: %w", path, err)
+	}
+	var lines []string
+	for _, l := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines, nil
+}
+
+type genericTemplateData struct {
+	Package  string
+	Import   string
+	Alias    string
+	Func     string
+	Params   []Param
+	SeedRows [][]string // SeedRows[i][j] is the j-th param's value for the i-th seed row
+}
+
+// Generate renders a *_fuzz_test.go source that seeds each parameter of
+// target from its Kind's corpus and calls target.Func, relying on the Go
+// fuzzing engine to report panics or hangs.
+func Generate(target Target) ([]byte, error) {
+	if len(target.Params) == 0 {
+		return nil, fmt.Errorf("fuzzgen: target %s has no parameters to fuzz", target.Func)
+	}
+
+	perParamSeeds := make([][]string, len(target.Params))
+	for i, p := range target.Params {
+		s, err := seeds(p.Kind)
+		if err != nil {
+			return nil, err
+		}
+		perParamSeeds[i] = s
+	}
+
+	data := genericTemplateData{
+		Package:  target.Package,
+		Import:   target.Import,
+		Alias:    AliasFor(target.Import),
+		Func:     target.Func,
+		Params:   target.Params,
+		SeedRows: zipSeeds(perParamSeeds),
+	}
+
+	var buf bytes.Buffer
+	if err := genericTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("fuzzgen: rendering template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// zipSeeds turns per-parameter seed lists into rows suitable for f.Add,
+// cycling shorter lists so every row has one value per parameter.
+func zipSeeds(perParamSeeds [][]string) [][]string {
+	rows := 0
+	for _, s := range perParamSeeds {
+		if len(s) > rows {
+			rows = len(s)
+		}
+	}
+	result := make([][]string, rows)
+	for row := 0; row < rows; row++ {
+		values := make([]string, len(perParamSeeds))
+		for i, s := range perParamSeeds {
+			if len(s) == 0 {
+				continue
+			}
+			values[i] = s[row%len(s)]
+		}
+		result[row] = values
+	}
+	return result
+}
+
+// AliasFor derives a valid Go import alias from importPath's last path
+// segment, replacing any character that can't appear in a Go identifier
+// with "_" and prefixing with "_" if the result would otherwise start
+// with a digit. Real module paths routinely end in segments containing
+// "-" or "." (e.g. "go-sql-driver/mysql", "pkg.go.dev/foo"), which are
+// not valid identifiers on their own.
+func AliasFor(importPath string) string {
+	parts := strings.Split(importPath, "/")
+	last := parts[len(parts)-1]
+
+	var sb strings.Builder
+	for _, r := range last {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune('_')
+		}
+	}
+	alias := sb.String()
+	if alias == "" || (alias[0] >= '0' && alias[0] <= '9') {
+		alias = "_" + alias
+	}
+	return alias
+}
+
+var genericTemplate = mustTemplate("fuzz", genericTemplateSrc)
+
+func mustTemplate(name, src string) *template.Template {
+	return template.Must(template.New(name).Funcs(template.FuncMap{
+		"quote": strconv.Quote,
+	}).Parse(src))
+}
+
+const genericTemplateSrc = `// Code generated by secassist fuzzgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"testing"
+
+	{{.Alias}} "{{.Import}}"
+)
+
+func Fuzz{{.Func}}(f *testing.F) {
+{{- range .SeedRows}}
+	f.Add({{range $i, $v := .}}{{if $i}}, {{end}}{{quote $v}}{{end}})
+{{- end}}
+
+	f.Fuzz(func(t *testing.T{{range .Params}}, {{.Name}} string{{end}}) {
+		{{.Alias}}.{{.Func}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}}{{end}})
+	})
+}
+`
@@ -0,0 +1,91 @@
+package fuzzgen
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestGenerateProducesParseableSource(t *testing.T) {
+	src, err := Generate(Target{
+		Package: "example_test",
+		Import:  "example.com/widget",
+		Func:    "Render",
+		Params:  []Param{{Name: "input", Kind: KindHTML}},
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	if _, err := parser.ParseFile(fset, "fuzz_test.go", src, 0); err != nil {
+		t.Fatalf("Generate produced unparseable source: %v\n%s", err, src)
+	}
+
+	if !strings.Contains(string(src), "func FuzzRender(f *testing.F)") {
+		t.Errorf("generated source missing expected fuzz func signature:\n%s", src)
+	}
+	if !strings.Contains(string(src), `widget "example.com/widget"`) {
+		t.Errorf("generated source missing expected import alias:\n%s", src)
+	}
+}
+
+func TestGenerateRejectsEmptyParams(t *testing.T) {
+	_, err := Generate(Target{Package: "p", Import: "example.com/widget", Func: "Render"})
+	if err == nil {
+		t.Fatal("Generate with no Params: got nil error, want one")
+	}
+}
+
+func TestGenerateRejectsUnknownKind(t *testing.T) {
+	_, err := Generate(Target{
+		Package: "p",
+		Import:  "example.com/widget",
+		Func:    "Render",
+		Params:  []Param{{Name: "input", Kind: Kind("bogus")}},
+	})
+	if err == nil {
+		t.Fatal("Generate with an unknown Kind: got nil error, want one")
+	}
+}
+
+func TestGenerateReadFileHarnessProducesParseableSource(t *testing.T) {
+	src, err := GenerateReadFileHarness(ReadFileTarget{
+		Package: "example_test",
+		Import:  "example.com/storage",
+		Func:    "ReadFile",
+	})
+	if err != nil {
+		t.Fatalf("GenerateReadFileHarness: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	if _, err := parser.ParseFile(fset, "fuzz_test.go", src, 0); err != nil {
+		t.Fatalf("GenerateReadFileHarness produced unparseable source: %v\n%s", err, src)
+	}
+
+	if !strings.Contains(string(src), "func FuzzReadFile(f *testing.F)") {
+		t.Errorf("generated source missing expected fuzz func signature:\n%s", src)
+	}
+	if !strings.Contains(string(src), "canaryContents") {
+		t.Errorf("generated source missing the path-traversal canary oracle:\n%s", src)
+	}
+}
+
+func TestAliasForSanitizesInvalidIdentifierChars(t *testing.T) {
+	cases := []struct {
+		importPath string
+		want       string
+	}{
+		{"example.com/widget", "widget"},
+		{"example.com/go-sql-driver", "go_sql_driver"},
+		{"pkg.go.dev/foo.bar", "foo_bar"},
+		{"example.com/123numeric", "_123numeric"},
+	}
+	for _, c := range cases {
+		if got := AliasFor(c.importPath); got != c.want {
+			t.Errorf("AliasFor(%q) = %q, want %q", c.importPath, got, c.want)
+		}
+	}
+}
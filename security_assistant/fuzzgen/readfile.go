@@ -0,0 +1,92 @@
+package fuzzgen
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// ReadFileTarget describes a function with the same shape as
+// safe.ReadFile(baseDir, path string) ([]byte, error): a fixed base
+// directory and a caller-controlled path, returning the file's bytes.
+type ReadFileTarget struct {
+	Package string
+	Import  string
+	Func    string
+}
+
+// GenerateReadFileHarness renders a *_fuzz_test.go that fuzzes the path
+// argument of a ReadFile-shaped function with the path-traversal corpus,
+// and asserts the oracle the fuzz-target-generator request calls out
+// explicitly: the function must never return bytes from outside its
+// declared base directory. It does this by pointing baseDir at a fresh
+// temp subdirectory and planting a canary file next to (not inside) it,
+// then failing if a fuzzed path ever causes the canary's contents to
+// come back.
+func GenerateReadFileHarness(target ReadFileTarget) ([]byte, error) {
+	s, err := seeds(KindPath)
+	if err != nil {
+		return nil, err
+	}
+
+	data := readFileTemplateData{
+		Package: target.Package,
+		Import:  target.Import,
+		Alias:   AliasFor(target.Import),
+		Func:    target.Func,
+		Seeds:   s,
+	}
+
+	var buf bytes.Buffer
+	if err := readFileTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("fuzzgen: rendering ReadFile harness: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+type readFileTemplateData struct {
+	Package string
+	Import  string
+	Alias   string
+	Func    string
+	Seeds   []string
+}
+
+var readFileTemplate = mustTemplate("readfile", readFileTemplateSrc)
+
+const readFileTemplateSrc = `// Code generated by secassist fuzzgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	{{.Alias}} "{{.Import}}"
+)
+
+const canaryContents = "fuzzgen-canary-do-not-return-me"
+
+func Fuzz{{.Func}}(f *testing.F) {
+{{- range .Seeds}}
+	f.Add({{quote .}})
+{{- end}}
+
+	f.Fuzz(func(t *testing.T, path string) {
+		root := t.TempDir()
+		baseDir := filepath.Join(root, "base")
+		if err := os.Mkdir(baseDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, "canary"), []byte(canaryContents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := {{.Alias}}.{{.Func}}(baseDir, path)
+		if err == nil && strings.Contains(string(data), canaryContents) {
+			t.Fatalf("{{.Func}}(%q, %q) returned data from outside its base directory", baseDir, path)
+		}
+	})
+}
+`
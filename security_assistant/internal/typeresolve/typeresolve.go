@@ -0,0 +1,106 @@
+// Package typeresolve provides best-effort go/types checking shared by
+// analyze and safe/vetcheck, both of which need to verify a call's actual
+// receiver or package qualifier before flagging it by selector name
+// alone.
+package typeresolve
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/token"
+	"go/types"
+)
+
+// Info holds the best-effort type-checking result for a single file,
+// used to verify a call's actual receiver or package qualifier before
+// flagging it by selector name alone. Type-checking one file in isolation
+// can fail - unresolved imports, helper types defined elsewhere in the
+// same package - so every lookup here treats "no info" as "can't tell"
+// rather than "no match": callers fall back to matching by bare selector
+// name when type information isn't available.
+type Info struct {
+	info *types.Info
+}
+
+// Resolver type-checks files using the source importer, which parses the
+// standard library itself rather than requiring pre-built compiler
+// archives. A single Resolver should be reused across every file in a
+// Run: the underlying importer caches packages it has already resolved
+// (e.g. "os/exec"), so checking a directory of files doesn't re-parse the
+// standard library from source once per file.
+type Resolver struct {
+	importer types.Importer
+}
+
+// NewResolver creates a Resolver whose importer cache is shared across
+// every Check call made on it.
+func NewResolver(fset *token.FileSet) *Resolver {
+	return &Resolver{importer: importer.ForCompiler(fset, "source", nil)}
+}
+
+// Check best-effort type-checks file using fset and r's shared importer.
+// It never returns an error: a file whose package can't be fully resolved
+// in isolation just yields an Info with no usable Uses/Selections, and
+// every lookup on it reports "unknown".
+func (r *Resolver) Check(fset *token.FileSet, file *ast.File) *Info {
+	info := &types.Info{
+		Uses:       map[*ast.Ident]types.Object{},
+		Selections: map[*ast.SelectorExpr]*types.Selection{},
+	}
+	conf := types.Config{
+		Importer: r.importer,
+		Error:    func(error) {}, // best-effort: keep whatever partial info type-checking produced
+	}
+	_, _ = conf.Check(file.Name.Name, fset, []*ast.File{file}, info)
+	return &Info{info: info}
+}
+
+// PackageQualifier reports the import path a selector expression's left-
+// hand identifier resolves to (e.g. the "exec" in exec.Command(...)
+// resolving to "os/exec"), so a package-level function can be matched
+// even under an import alias, and not matched when some unrelated local
+// variable happens to share the package's conventional name.
+func (t *Info) PackageQualifier(sel *ast.SelectorExpr) (string, bool) {
+	if t == nil || t.info == nil {
+		return "", false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	obj, ok := t.info.Uses[ident]
+	if !ok {
+		return "", false
+	}
+	pkgName, ok := obj.(*types.PkgName)
+	if !ok {
+		return "", false
+	}
+	return pkgName.Imported().Path(), true
+}
+
+// ReceiverType reports the fully qualified type name (e.g.
+// "database/sql.DB") that a method selector's receiver resolves to,
+// unwrapping any pointer.
+func (t *Info) ReceiverType(sel *ast.SelectorExpr) (string, bool) {
+	if t == nil || t.info == nil {
+		return "", false
+	}
+	selection, ok := t.info.Selections[sel]
+	if !ok {
+		return "", false
+	}
+	recv := selection.Recv()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	named, ok := recv.(*types.Named)
+	if !ok {
+		return "", false
+	}
+	obj := named.Obj()
+	if obj.Pkg() == nil {
+		return obj.Name(), true
+	}
+	return obj.Pkg().Path() + "." + obj.Name(), true
+}
@@ -0,0 +1,85 @@
+package patterns
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+)
+
+//go:embed data/*.json
+var builtinFS embed.FS
+
+// Provider lets callers contribute additional patterns without forking
+// this package, e.g. a project-specific corpus loaded from its own
+// embedded or on-disk JSON.
+type Provider interface {
+	Patterns() ([]Pattern, error)
+}
+
+// providerFunc adapts a plain function to Provider.
+type providerFunc func() ([]Pattern, error)
+
+func (f providerFunc) Patterns() ([]Pattern, error) { return f() }
+
+var providers []Provider
+
+// Register adds p to the set of providers consulted by LoadAll. It is
+// meant to be called from an init function in a plugin package.
+func Register(p Provider) {
+	providers = append(providers, p)
+}
+
+// FromJSON returns a Provider that decodes a corpus from raw JSON,
+// suitable for wrapping a project's own go:embed'd file.
+func FromJSON(data []byte) Provider {
+	return providerFunc(func() ([]Pattern, error) {
+		return decode(data)
+	})
+}
+
+// LoadAll returns the built-in corpus plus every pattern contributed by a
+// registered Provider.
+func LoadAll() ([]Pattern, error) {
+	all, err := loadBuiltin()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range providers {
+		extra, err := p.Patterns()
+		if err != nil {
+			return nil, fmt.Errorf("patterns: loading provider: %w", err)
+		}
+		all = append(all, extra...)
+	}
+	return all, nil
+}
+
+func loadBuiltin() ([]Pattern, error) {
+	entries, err := builtinFS.ReadDir("data")
+	if err != nil {
+		return nil, fmt.Errorf("patterns: reading embedded corpus: %w", err)
+	}
+
+	var all []Pattern
+	for _, entry := range entries {
+		data, err := builtinFS.ReadFile(path.Join("data", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("patterns: reading %s: %w", entry.Name(), err)
+		}
+		decoded, err := decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("patterns: decoding %s: %w", entry.Name(), err)
+		}
+		all = append(all, decoded...)
+	}
+	return all, nil
+}
+
+func decode(data []byte) ([]Pattern, error) {
+	var out []Pattern
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,197 @@
+// Package patterns is a versioned corpus of historical Go-relevant
+// vulnerability signatures, reusing the four classes analyzed by
+// security_assistant/analyze and the vulnerable/secure pairs documented
+// in security_assistant/remediation/code_examples. It exposes a
+// Match API for scanning arbitrary source, plus a Provider interface so
+// callers can contribute additional signatures without forking.
+package patterns
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+
+	"security_assistant/analyze"
+	"security_assistant/remediation/fix"
+)
+
+// Pattern is a single detection rule: a regex predicate paired with a
+// reference vulnerable/secure example and enough metadata to report a
+// Finding.
+type Pattern struct {
+	ID                string        `json:"id"`
+	Title             string        `json:"title"`
+	Class             analyze.Class `json:"class"`
+	CWE               string        `json:"cwe"`
+	Severity          string        `json:"severity"`
+	Description       string        `json:"description"`
+	Regex             string        `json:"regex"`
+	VulnerableExample string        `json:"vulnerable_example"`
+	SecureExample     string        `json:"secure_example"`
+	AutoFix           bool          `json:"auto_fix"`
+
+	compiled *regexp.Regexp
+}
+
+// compile lazily compiles Regex, caching the result on the Pattern.
+func (p *Pattern) compile() (*regexp.Regexp, error) {
+	if p.compiled != nil {
+		return p.compiled, nil
+	}
+	re, err := regexp.Compile(p.Regex)
+	if err != nil {
+		return nil, fmt.Errorf("patterns: compiling regex for %s: %w", p.ID, err)
+	}
+	p.compiled = re
+	return re, nil
+}
+
+// classKinds maps an analyze.Class to the fix.Kind(s) a rewrite of that
+// class is reported under. There's a 1:1 correspondence today, but a
+// Class could plausibly be addressed by more than one rewriter shape in
+// the future, so this is a slice rather than a single value.
+var classKinds = map[analyze.Class][]fix.Kind{
+	analyze.ClassSQLInjection:     {fix.KindSQLInjection},
+	analyze.ClassCommandInjection: {fix.KindCommandInjection},
+	analyze.ClassPathTraversal:    {fix.KindPathTraversal},
+	analyze.ClassXSS:              {fix.KindXSS},
+}
+
+// Fix is the hook AutoFix promises: if p is marked auto-fixable, it runs
+// filename/src through remediation/fix's AST-based rewriters and reports
+// whether anything in p's class actually got rewritten. fix.Rewrite
+// operates on the whole file, so a file can have several different
+// vulnerability classes rewritten in one pass - ok only reports true when
+// one of those rewrites is attributable to p's own Class, never when an
+// unrelated class elsewhere in the file happened to get fixed instead. A
+// pattern with AutoFix false - or one whose Class fix's rewriters don't
+// recognize, or whose regex matches a shape fix's rewriters don't
+// recognize - reports ok == false rather than attempting a rewrite, the
+// same as if Fix were never called.
+func (p Pattern) Fix(filename string, src []byte) (result *fix.Result, ok bool, err error) {
+	if !p.AutoFix {
+		return nil, false, nil
+	}
+	kinds, known := classKinds[p.Class]
+	if !known {
+		return nil, false, nil
+	}
+	result, err = fix.Rewrite(filename, src)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, k := range kinds {
+		if result.HasKind(k) {
+			return result, true, nil
+		}
+	}
+	return result, false, nil
+}
+
+// Finding is a single pattern match against a piece of source.
+type Finding struct {
+	PatternID string
+	Class     analyze.Class
+	CWE       string
+	Severity  string
+	Message   string
+	Line      int
+	Match     string
+}
+
+// Match scans source against every pattern in patternList and returns one
+// Finding per match. Matching runs over the whole source rather than
+// line-by-line so that patterns describing a short idiom spanning
+// adjacent lines (e.g. an assignment immediately followed by the call it
+// feeds) can be expressed with a literal "\n" in the regex.
+//
+// A regex hit is paired with an AST predicate before it's reported: a hit
+// that falls entirely inside a comment (vulnerable-looking text someone
+// wrote up in a // note, not live code) is discarded. source doesn't have
+// to be a complete file - fixture snippets in VulnerableExample/
+// SecureExample aren't - so the predicate parses a best-effort wrapped
+// copy of source purely to locate comments; if even that fails to parse,
+// it has no comment spans to exclude and every regex hit is reported, the
+// same as before this predicate existed.
+func Match(source []byte, patternList []Pattern) ([]Finding, error) {
+	comments := commentSpans(source)
+
+	var findings []Finding
+	for i := range patternList {
+		p := &patternList[i]
+		re, err := p.compile()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, loc := range re.FindAllIndex(source, -1) {
+			if inAnySpan(loc[0], comments) {
+				continue
+			}
+			findings = append(findings, Finding{
+				PatternID: p.ID,
+				Class:     p.Class,
+				CWE:       p.CWE,
+				Severity:  p.Severity,
+				Message:   p.Title,
+				Line:      1 + bytes.Count(source[:loc[0]], []byte("\n")),
+				Match:     string(source[loc[0]:loc[1]]),
+			})
+		}
+	}
+	return findings, nil
+}
+
+// byteSpan is a half-open [start, end) byte range in the original source.
+type byteSpan struct {
+	start, end int
+}
+
+// commentSpans locates every comment in source, which `secassist patterns
+// match` passes as a complete file but Verify's own fixtures pass as a
+// bare statement list. It tries parsing source as-is first (the complete-
+// file case); if that fails, it retries with source wrapped in a synthetic
+// "package p; func _() { ... }" so fixture snippets parse too, translating
+// offsets back to source's own byte positions. If neither parses (source
+// isn't valid Go at all), it returns nil and Match falls back to matching
+// every regex hit, as it did before this predicate existed.
+func commentSpans(source []byte) []byteSpan {
+	fset := token.NewFileSet()
+	if file, err := parser.ParseFile(fset, "", source, parser.ParseComments); err == nil {
+		return spansFromComments(fset, file.Comments, 0)
+	}
+
+	const prefix = "package p\nfunc _() {\n"
+	wrapped := append([]byte(prefix), source...)
+	wrapped = append(wrapped, []byte("\n}\n")...)
+
+	fset = token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", wrapped, parser.ParseComments)
+	if err != nil {
+		return nil
+	}
+	return spansFromComments(fset, file.Comments, len(prefix))
+}
+
+func spansFromComments(fset *token.FileSet, comments []*ast.CommentGroup, prefixLen int) []byteSpan {
+	var spans []byteSpan
+	for _, group := range comments {
+		spans = append(spans, byteSpan{
+			start: fset.Position(group.Pos()).Offset - prefixLen,
+			end:   fset.Position(group.End()).Offset - prefixLen,
+		})
+	}
+	return spans
+}
+
+func inAnySpan(offset int, spans []byteSpan) bool {
+	for _, s := range spans {
+		if offset >= s.start && offset < s.end {
+			return true
+		}
+	}
+	return false
+}
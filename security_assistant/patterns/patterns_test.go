@@ -0,0 +1,174 @@
+package patterns
+
+import (
+	"testing"
+
+	"security_assistant/analyze"
+)
+
+func TestLoadAllBuiltinCorpusVerifies(t *testing.T) {
+	corpus, err := LoadAll()
+	if err != nil {
+		t.Fatalf("LoadAll: %v", err)
+	}
+	if len(corpus) == 0 {
+		t.Fatal("LoadAll returned no patterns")
+	}
+
+	results, err := Verify(corpus)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if len(results) != len(corpus) {
+		t.Fatalf("Verify returned %d results for %d patterns", len(results), len(corpus))
+	}
+	for _, r := range results {
+		if !r.MatchedVuln {
+			t.Errorf("%s: did not match its own vulnerable_example", r.PatternID)
+		}
+		if !r.SilentOnFixed {
+			t.Errorf("%s: matched its own secure_example", r.PatternID)
+		}
+	}
+}
+
+func TestMatchFindsVulnerableExample(t *testing.T) {
+	p := Pattern{
+		ID:    "test-pattern",
+		Title: "test",
+		Regex: `db\.Query\(query\)`,
+	}
+	findings, err := Match([]byte("query := buildQuery()\nrows, err := db.Query(query)"), []Pattern{p})
+	if err != nil {
+		t.Fatalf("Match: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1", len(findings))
+	}
+	if findings[0].Line != 2 {
+		t.Errorf("got Line %d, want 2", findings[0].Line)
+	}
+}
+
+func TestMatchIgnoresCommentedOutCode(t *testing.T) {
+	p := Pattern{
+		ID:    "test-pattern",
+		Title: "test",
+		Regex: `db\.Query\(query\)`,
+	}
+	source := []byte("// example of the bug: rows, err := db.Query(query)\nfmt.Println(\"ok\")")
+	findings, err := Match(source, []Pattern{p})
+	if err != nil {
+		t.Fatalf("Match: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Fatalf("got %d findings for a match inside a comment, want 0", len(findings))
+	}
+}
+
+func TestPatternFixAppliesRewriteWhenAutoFixSet(t *testing.T) {
+	p := Pattern{ID: "test-sql", Class: analyze.ClassSQLInjection, AutoFix: true}
+	src := []byte(`package vuln
+
+import "database/sql"
+
+func handler(db *sql.DB, username string) error {
+	query := "SELECT * FROM users WHERE username = '" + username + "'"
+	rows, err := db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	return nil
+}
+`)
+	result, ok, err := p.Fix("handler.go", src)
+	if err != nil {
+		t.Fatalf("Fix: %v", err)
+	}
+	if !ok {
+		t.Fatal("Fix reported ok=false for a shape remediation/fix recognizes")
+	}
+	if !result.Changed {
+		t.Fatal("Fix's own result reports Changed=false")
+	}
+}
+
+func TestPatternFixNoOpWhenAutoFixUnset(t *testing.T) {
+	p := Pattern{ID: "test-sql", AutoFix: false}
+	result, ok, err := p.Fix("handler.go", []byte("package vuln\n"))
+	if err != nil {
+		t.Fatalf("Fix: %v", err)
+	}
+	if ok || result != nil {
+		t.Fatalf("Fix should no-op when AutoFix is false, got result=%v ok=%v", result, ok)
+	}
+}
+
+// TestPatternFixDoesNotClaimAnUnrelatedRewrite covers a file with two
+// findings of different classes: a SQL-injection assignment that the
+// rewriter's adjacency check misses (the intervening log.Println breaks
+// the assign-then-call pairing matchSQLConcat requires) and a
+// command-injection call that it recognizes and rewrites. fix.Rewrite
+// changes the file, but only the command-injection shape - a
+// SQL-injection Pattern.Fix must report ok=false rather than claiming
+// credit for someone else's rewrite.
+func TestPatternFixDoesNotClaimAnUnrelatedRewrite(t *testing.T) {
+	src := []byte(`package vuln
+
+import (
+	"database/sql"
+	"log"
+	"os/exec"
+)
+
+func handler(db *sql.DB, username, userInput string) error {
+	query := "SELECT * FROM users WHERE username = '" + username + "'"
+	log.Println("about to query")
+	rows, err := db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	cmd := exec.Command("sh", "-c", "ping "+userInput)
+	return cmd.Run()
+}
+`)
+
+	sqlPattern := Pattern{ID: "test-sql", Class: analyze.ClassSQLInjection, AutoFix: true}
+	result, ok, err := sqlPattern.Fix("handler.go", src)
+	if err != nil {
+		t.Fatalf("Fix: %v", err)
+	}
+	if !result.Changed {
+		t.Fatal("expected the command-injection shape to be rewritten, but Changed=false")
+	}
+	if ok {
+		t.Fatalf("SQL-injection Pattern.Fix reported ok=true for a rewrite that only touched command injection:\n%s", result.Rewritten)
+	}
+
+	cmdPattern := Pattern{ID: "test-cmd", Class: analyze.ClassCommandInjection, AutoFix: true}
+	result, ok, err = cmdPattern.Fix("handler.go", src)
+	if err != nil {
+		t.Fatalf("Fix: %v", err)
+	}
+	if !ok {
+		t.Fatalf("command-injection Pattern.Fix reported ok=false for a shape it does rewrite:\n%s", result.Rewritten)
+	}
+}
+
+func TestMatchFallsBackWhenSourceIsntValidGo(t *testing.T) {
+	p := Pattern{
+		ID:    "test-pattern",
+		Title: "test",
+		Regex: `needle`,
+	}
+	findings, err := Match([]byte("not valid go ( at all { needle"), []Pattern{p})
+	if err != nil {
+		t.Fatalf("Match: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1 (no comment spans to filter against)", len(findings))
+	}
+}
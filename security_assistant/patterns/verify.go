@@ -0,0 +1,44 @@
+package patterns
+
+import "fmt"
+
+// VerifyResult records whether a single pattern correctly matched its own
+// vulnerable fixture and correctly stayed silent on its secure fixture.
+type VerifyResult struct {
+	PatternID     string
+	MatchedVuln   bool
+	SilentOnFixed bool
+}
+
+// OK reports whether the pattern behaved as its own fixtures expect.
+func (r VerifyResult) OK() bool {
+	return r.MatchedVuln && r.SilentOnFixed
+}
+
+// Verify compiles every pattern in patternList and checks it against its
+// own VulnerableExample and SecureExample fixtures: it must match the
+// former and stay silent on the latter. This is what `secassist patterns
+// verify` runs before trusting a corpus (built-in or contributed via a
+// Provider).
+func Verify(patternList []Pattern) ([]VerifyResult, error) {
+	results := make([]VerifyResult, 0, len(patternList))
+	for i := range patternList {
+		p := patternList[i]
+
+		vulnFindings, err := Match([]byte(p.VulnerableExample), []Pattern{p})
+		if err != nil {
+			return nil, fmt.Errorf("patterns: verifying %s against vulnerable fixture: %w", p.ID, err)
+		}
+		fixedFindings, err := Match([]byte(p.SecureExample), []Pattern{p})
+		if err != nil {
+			return nil, fmt.Errorf("patterns: verifying %s against secure fixture: %w", p.ID, err)
+		}
+
+		results = append(results, VerifyResult{
+			PatternID:     p.ID,
+			MatchedVuln:   len(vulnFindings) > 0,
+			SilentOnFixed: len(fixedFindings) == 0,
+		})
+	}
+	return results, nil
+}
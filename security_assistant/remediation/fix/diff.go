@@ -0,0 +1,197 @@
+package fix
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines of context kept on each
+// side of a changed region, matching the default of `diff -u`/`git diff`.
+const contextLines = 3
+
+// unifiedDiff produces a real unified diff (with @@ hunk headers) between
+// before and after, labeled with filename, so the output can be fed to
+// `patch -p1` or `git apply` like any other diff. It uses a
+// straightforward longest-common-subsequence line diff; these files are
+// small (single functions or snippets), so quadratic behavior is not a
+// concern.
+func unifiedDiff(filename string, before, after []byte) string {
+	a := strings.Split(strings.TrimSuffix(string(before), "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(string(after), "\n"), "\n")
+	ops := diffLines(a, b)
+	hunks := buildHunks(ops)
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	// a/ and b/ prefixes expect a path relative to some root; an absolute
+	// filename would otherwise double up the slash (e.g. "a//tmp/...").
+	// Strip the leading slash the same way `git diff --no-index` does.
+	relName := strings.TrimPrefix(filename, "/")
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "--- a/%s\n", relName)
+	fmt.Fprintf(&buf, "+++ b/%s\n", relName)
+	for _, h := range hunks {
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n", rangeStr(h.oldStart, h.oldCount), rangeStr(h.newStart, h.newCount))
+		for _, op := range h.ops {
+			switch op.kind {
+			case diffEqual:
+				fmt.Fprintf(&buf, " %s\n", op.text)
+			case diffDelete:
+				fmt.Fprintf(&buf, "-%s\n", op.text)
+			case diffInsert:
+				fmt.Fprintf(&buf, "+%s\n", op.text)
+			}
+		}
+	}
+	return buf.String()
+}
+
+// rangeStr formats a hunk's "start,count" range, omitting the count when
+// it's the conventional 1 (matching diff's own output).
+func rangeStr(start, count int) string {
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+type diffKind int
+
+const (
+	diffEqual diffKind = iota
+	diffDelete
+	diffInsert
+)
+
+type diffOp struct {
+	kind diffKind
+	text string
+	// aPos/bPos is the 0-based number of lines already consumed from a/b
+	// immediately before this op, i.e. its position in the old/new file.
+	// Both are kept regardless of op kind so hunk boundaries can be
+	// computed uniformly.
+	aPos, bPos int
+}
+
+// diffLines computes a line-level diff between a and b using an LCS table.
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{diffEqual, a[i], i, j})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{diffDelete, a[i], i, j})
+			i++
+		default:
+			ops = append(ops, diffOp{diffInsert, b[j], i, j})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{diffDelete, a[i], i, j})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{diffInsert, b[j], i, j})
+	}
+	return ops
+}
+
+// hunk is one @@ ... @@ region: a contiguous run of ops (changed lines
+// plus their surrounding context) and the old/new line ranges it covers.
+type hunk struct {
+	oldStart, oldCount int
+	newStart, newCount int
+	ops                []diffOp
+}
+
+// buildHunks groups ops into hunks the way `diff -u` does: each changed
+// line gets contextLines of unchanged context on either side, and hunks
+// whose context would overlap (or touch) are merged into one.
+func buildHunks(ops []diffOp) []hunk {
+	var changed []int
+	for idx, op := range ops {
+		if op.kind != diffEqual {
+			changed = append(changed, idx)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	var ranges [][2]int // [start, end] op indices, inclusive
+	start, end := changed[0], changed[0]
+	for _, idx := range changed[1:] {
+		if idx-end <= 2*contextLines {
+			end = idx
+			continue
+		}
+		ranges = append(ranges, [2]int{start, end})
+		start, end = idx, idx
+	}
+	ranges = append(ranges, [2]int{start, end})
+
+	var hunks []hunk
+	for _, r := range ranges {
+		lo := r[0] - contextLines
+		if lo < 0 {
+			lo = 0
+		}
+		hi := r[1] + contextLines
+		if hi > len(ops)-1 {
+			hi = len(ops) - 1
+		}
+		hunkOps := ops[lo : hi+1]
+
+		oldCount, newCount := 0, 0
+		for _, op := range hunkOps {
+			switch op.kind {
+			case diffEqual:
+				oldCount++
+				newCount++
+			case diffDelete:
+				oldCount++
+			case diffInsert:
+				newCount++
+			}
+		}
+		first := ops[lo]
+		oldStart, newStart := first.aPos, first.bPos
+		if oldCount > 0 {
+			oldStart++
+		}
+		if newCount > 0 {
+			newStart++
+		}
+
+		hunks = append(hunks, hunk{
+			oldStart: oldStart, oldCount: oldCount,
+			newStart: newStart, newCount: newCount,
+			ops: hunkOps,
+		})
+	}
+	return hunks
+}
@@ -0,0 +1,26 @@
+package fix
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestUnifiedDiffAbsolutePath covers the a//... doubled-slash bug: an
+// absolute filename must not produce a header that patch -p1/git apply
+// reject.
+func TestUnifiedDiffAbsolutePath(t *testing.T) {
+	before := []byte("package vuln\n\nfunc f() int {\n\treturn 1\n}\n")
+	after := []byte("package vuln\n\nfunc f() int {\n\treturn 2\n}\n")
+
+	diff := unifiedDiff("/tmp/fixtest/vuln.go", before, after)
+
+	if !strings.Contains(diff, "--- a/tmp/fixtest/vuln.go\n") {
+		t.Errorf("diff header missing expected --- line:\n%s", diff)
+	}
+	if !strings.Contains(diff, "+++ b/tmp/fixtest/vuln.go\n") {
+		t.Errorf("diff header missing expected +++ line:\n%s", diff)
+	}
+	if strings.Contains(diff, "a//") || strings.Contains(diff, "b//") {
+		t.Errorf("diff header has a doubled slash, which patch -p1/git apply reject:\n%s", diff)
+	}
+}
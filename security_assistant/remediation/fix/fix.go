@@ -0,0 +1,188 @@
+// Package fix rewrites the vulnerable patterns documented in
+// security_assistant/remediation/code_examples into their secure
+// counterparts. It operates purely on syntax (go/ast), matching the same
+// call shapes analyze's taint sinks look for, and prints a unified diff
+// of the result.
+package fix
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"sort"
+)
+
+// Result is the outcome of running Rewrite over a source file.
+type Result struct {
+	Filename     string
+	Original     []byte
+	Rewritten    []byte
+	Changed      bool
+	ChangedKinds []Kind
+	Diff         string
+}
+
+// HasKind reports whether Rewrite touched anything belonging to kind.
+func (res *Result) HasKind(kind Kind) bool {
+	for _, k := range res.ChangedKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Rewrite parses src (from filename, used only for diagnostics and the
+// diff header) and rewrites every recognized vulnerable pattern in place.
+func Rewrite(filename string, src []byte) (*Result, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("fix: parsing %s: %w", filename, err)
+	}
+
+	r := &rewriter{file: file}
+	r.run()
+
+	if !r.changed {
+		return &Result{Filename: filename, Original: src, Rewritten: src, Changed: false}, nil
+	}
+
+	for _, imp := range r.neededImports {
+		addImport(file, imp)
+	}
+	removeUnusedImports(file)
+	ast.SortImports(fset, file)
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, fmt.Errorf("fix: formatting rewritten %s: %w", filename, err)
+	}
+
+	var kinds []Kind
+	for k := range r.changedKinds {
+		kinds = append(kinds, k)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+
+	rewritten := buf.Bytes()
+	return &Result{
+		Filename:     filename,
+		Original:     src,
+		Rewritten:    rewritten,
+		Changed:      true,
+		ChangedKinds: kinds,
+		Diff:         unifiedDiff(filename, src, rewritten),
+	}, nil
+}
+
+// addImport inserts path into file's import declaration if it isn't
+// already present. This is the hand-rolled equivalent of
+// astutil.AddImport, done without pulling in golang.org/x/tools.
+func addImport(file *ast.File, path string) {
+	for _, imp := range file.Imports {
+		if importPath(imp) == path {
+			return
+		}
+	}
+
+	spec := &ast.ImportSpec{Path: &ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf("%q", path)}}
+	file.Imports = append(file.Imports, spec)
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		gen.Specs = append(gen.Specs, spec)
+		if !gen.Lparen.IsValid() {
+			// Was a single, unparenthesized import; go/printer will
+			// parenthesize it regardless now that it has >1 spec, but
+			// ast.SortImports only sorts specs inside a decl whose
+			// Lparen is already valid, so mark it as a block.
+			gen.Lparen = token.Pos(1)
+		}
+		return
+	}
+
+	// No existing import block; add one at the top of the file.
+	gen := &ast.GenDecl{Tok: token.IMPORT, Lparen: token.Pos(1), Specs: []ast.Spec{spec}}
+	file.Decls = append([]ast.Decl{gen}, file.Decls...)
+}
+
+func importPath(imp *ast.ImportSpec) string {
+	if imp.Path == nil {
+		return ""
+	}
+	v := imp.Path.Value
+	if len(v) >= 2 {
+		return v[1 : len(v)-1]
+	}
+	return v
+}
+
+// removeUnusedImports drops import specs whose local package name no
+// longer appears anywhere in file. A rewrite can leave an import dead -
+// matchXSS replacing a file's only fmt.Fprintf call with tmpl.Execute
+// leaves "fmt" imported but unused - so this runs once, after all
+// rewrites and added imports, rather than being each rewrite's job.
+func removeUnusedImports(file *ast.File) {
+	used := map[string]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	keepImport := func(imp *ast.ImportSpec) bool {
+		name := importLocalName(imp)
+		return name == "_" || name == "." || used[name]
+	}
+
+	var keptImports []*ast.ImportSpec
+	for _, imp := range file.Imports {
+		if keepImport(imp) {
+			keptImports = append(keptImports, imp)
+		}
+	}
+	file.Imports = keptImports
+
+	var decls []ast.Decl
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			decls = append(decls, decl)
+			continue
+		}
+		var specs []ast.Spec
+		for _, spec := range gen.Specs {
+			if keepImport(spec.(*ast.ImportSpec)) {
+				specs = append(specs, spec)
+			}
+		}
+		if len(specs) == 0 {
+			continue
+		}
+		gen.Specs = specs
+		decls = append(decls, gen)
+	}
+	file.Decls = decls
+}
+
+// importLocalName reports the identifier a file uses to refer to imp: its
+// alias, or (absent one) the conventional last path component, which
+// matches the real package name for every stdlib import this tool adds
+// or encounters.
+func importLocalName(imp *ast.ImportSpec) string {
+	if imp.Name != nil {
+		return imp.Name.Name
+	}
+	return path.Base(importPath(imp))
+}
@@ -0,0 +1,640 @@
+package fix
+
+import (
+	"go/ast"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Kind identifies which vulnerable shape a rewrite addressed, so callers
+// that match findings to specific vulnerability classes (patterns.Pattern.Fix,
+// in particular) can tell which of a file's possibly-several rewrites is
+// the one they asked about.
+type Kind string
+
+const (
+	KindSQLInjection     Kind = "sql-injection"
+	KindCommandInjection Kind = "command-injection"
+	KindPathTraversal    Kind = "path-traversal"
+	KindXSS              Kind = "xss"
+)
+
+// rewriter walks a parsed file and replaces vulnerable statement shapes
+// with their secure counterparts, recording which extra imports the
+// replacements need and which Kinds were actually rewritten.
+type rewriter struct {
+	file          *ast.File
+	changed       bool
+	changedKinds  map[Kind]bool
+	neededImports []string
+}
+
+func (r *rewriter) markChanged(kind Kind) {
+	r.changed = true
+	if r.changedKinds == nil {
+		r.changedKinds = make(map[Kind]bool)
+	}
+	r.changedKinds[kind] = true
+}
+
+func (r *rewriter) requireImport(path string) {
+	for _, p := range r.neededImports {
+		if p == path {
+			return
+		}
+	}
+	r.neededImports = append(r.neededImports, path)
+}
+
+// run walks every block statement in the file, rewriting vulnerable
+// shapes in place. It tracks the ast.FieldList of the innermost enclosing
+// function (FuncDecl or FuncLit) so that rewrites needing to synthesize a
+// `return ...` know how many result values - and of what type - the
+// enclosing function actually has.
+func (r *rewriter) run() {
+	var stack []*ast.FieldList
+	ast.Inspect(r.file, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		var cur *ast.FieldList
+		if len(stack) > 0 {
+			cur = stack[len(stack)-1]
+		}
+		switch node := n.(type) {
+		case *ast.FuncDecl:
+			cur = node.Type.Results
+		case *ast.FuncLit:
+			cur = node.Type.Results
+		case *ast.BlockStmt:
+			node.List = r.rewriteStmts(node.List, cur)
+		}
+		stack = append(stack, cur)
+		return true
+	})
+}
+
+// rewriteStmts scans a statement list for the vulnerable shapes documented
+// in remediation/code_examples and replaces each one with its secure
+// equivalent. results is the ast.FieldList of the innermost enclosing
+// function, used by rewrites that need to synthesize a return statement
+// matching the function's actual signature.
+func (r *rewriter) rewriteStmts(list []ast.Stmt, results *ast.FieldList) []ast.Stmt {
+	out := make([]ast.Stmt, 0, len(list))
+	i := 0
+	for i < len(list) {
+		if i+1 < len(list) {
+			if repl, ok := r.matchSQLConcat(list[i], list[i+1]); ok {
+				out = append(out, repl...)
+				i += 2
+				continue
+			}
+			if repl, ok := r.matchPathTraversal(list[i], list[i+1], results); ok {
+				out = append(out, repl...)
+				i += 2
+				continue
+			}
+		}
+		if repl, ok := r.matchCommandInjection(list[i], results); ok {
+			out = append(out, repl...)
+			i++
+			continue
+		}
+		if repl, ok := r.matchXSS(list[i]); ok {
+			out = append(out, repl...)
+			i++
+			continue
+		}
+		out = append(out, list[i])
+		i++
+	}
+	return out
+}
+
+// --- return-signature helpers ---
+
+// resultTypes flattens a function's result field list into one entry per
+// return value (a field with multiple names still counts once per name).
+func resultTypes(results *ast.FieldList) []ast.Expr {
+	if results == nil {
+		return nil
+	}
+	var types []ast.Expr
+	for _, f := range results.List {
+		n := len(f.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			types = append(types, f.Type)
+		}
+	}
+	return types
+}
+
+// zeroValueFor returns an expression for the zero value of t and whether t
+// is a type we actually know the zero value of. For *ast.Ident it only
+// recognizes the built-in value types plus "error" and "any"/"interface{}"
+// spelled as an identifier; named types (structs, and any other identifier
+// referring to a declared type) aren't resolvable without go/types, so they
+// report false rather than guessing nil. Pointer, slice, map, chan, func and
+// interface types are nil-able and report true with a literal `nil`.
+func zeroValueFor(t ast.Expr) (ast.Expr, bool) {
+	switch typ := t.(type) {
+	case *ast.StarExpr, *ast.ArrayType, *ast.MapType, *ast.ChanType, *ast.FuncType, *ast.InterfaceType:
+		return ast.NewIdent("nil"), true
+	case *ast.Ident:
+		switch typ.Name {
+		case "string":
+			return &ast.BasicLit{Kind: token.STRING, Value: `""`}, true
+		case "bool":
+			return ast.NewIdent("false"), true
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+			"float32", "float64", "byte", "rune":
+			return &ast.BasicLit{Kind: token.INT, Value: "0"}, true
+		case "error", "any":
+			return ast.NewIdent("nil"), true
+		default:
+			// Could be a named struct, array, or other value type we
+			// can't safely zero as nil without resolving the
+			// declaration - bail rather than emit code that won't
+			// compile.
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+}
+
+// buildGuardReturn synthesizes a `return <zero values...>, errExpr`
+// statement matching results, the enclosing function's actual result
+// list. It only succeeds when the function's last result is a plain
+// `error` and every leading result is a type zeroValueFor actually knows
+// how to zero - those are the only shapes a guard clause can safely
+// satisfy without knowing the meaning of the other result values - so
+// callers must treat a false ok as "don't rewrite this site".
+func buildGuardReturn(results *ast.FieldList, errExpr ast.Expr) (ast.Stmt, bool) {
+	types := resultTypes(results)
+	if len(types) == 0 {
+		return nil, false
+	}
+	last, ok := types[len(types)-1].(*ast.Ident)
+	if !ok || last.Name != "error" {
+		return nil, false
+	}
+	exprs := make([]ast.Expr, len(types))
+	for i := 0; i < len(types)-1; i++ {
+		zero, ok := zeroValueFor(types[i])
+		if !ok {
+			return nil, false
+		}
+		exprs[i] = zero
+	}
+	exprs[len(types)-1] = errExpr
+	return &ast.ReturnStmt{Results: exprs}, true
+}
+
+func newErrorsNew(message string) ast.Expr {
+	return &ast.CallExpr{
+		Fun:  &ast.SelectorExpr{X: ast.NewIdent("errors"), Sel: ast.NewIdent("New")},
+		Args: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(message)}},
+	}
+}
+
+// --- SQL injection: `q := "..." + x + "..."` followed by `db.Query(q)` ---
+
+func (r *rewriter) matchSQLConcat(assignStmt, callStmt ast.Stmt) ([]ast.Stmt, bool) {
+	assign, ok := assignStmt.(*ast.AssignStmt)
+	if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil, false
+	}
+	queryIdent, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+	parts, ok := flattenConcat(assign.Rhs[0])
+	if !ok || !containsNonLiteral(parts) {
+		return nil, false
+	}
+
+	call, args, ok := callWithSoleArgIdent(callStmt, queryIdent.Name, "Query", "Exec", "QueryRow", "QueryContext", "ExecContext")
+	if !ok {
+		return nil, false
+	}
+
+	placeholder, params := parameterize(parts)
+
+	r.markChanged(KindSQLInjection)
+	assign.Rhs[0] = &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(placeholder)}
+	call.Args = append([]ast.Expr{args[0]}, params...)
+	for _, p := range params {
+		// params are expressions lifted out of the concatenation on the
+		// line above and spliced into this call; left with their
+		// original positions, go/printer sees a line mismatch against
+		// the call's other (real) args and breaks the call across
+		// lines. Clearing them makes the printer treat them like any
+		// other synthesized node.
+		clearPositions(p)
+	}
+	return []ast.Stmt{assign, callStmt}, true
+}
+
+// clearPositions recursively zeroes every token.Pos field in n's subtree.
+func clearPositions(n ast.Node) {
+	ast.Inspect(n, func(n ast.Node) bool {
+		if n == nil {
+			return false
+		}
+		v := reflect.ValueOf(n)
+		if v.Kind() != reflect.Ptr || v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Field(i)
+			if f.CanSet() && f.Type() == reflect.TypeOf(token.Pos(0)) {
+				f.SetInt(0)
+			}
+		}
+		return true
+	})
+}
+
+// flattenConcat flattens a chain of string `+` operands into order.
+func flattenConcat(expr ast.Expr) ([]ast.Expr, bool) {
+	bin, ok := expr.(*ast.BinaryExpr)
+	if !ok || bin.Op != token.ADD {
+		// A bare literal or identifier is a trivial one-part "concatenation".
+		return []ast.Expr{expr}, false
+	}
+	left, _ := flattenConcat(bin.X)
+	return append(left, bin.Y), true
+}
+
+func containsNonLiteral(parts []ast.Expr) bool {
+	for _, p := range parts {
+		if _, ok := p.(*ast.BasicLit); !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// parameterize turns a flattened concatenation into a single placeholder
+// string (string literal fragments are kept verbatim, everything else
+// becomes a `?`) plus the ordered list of expressions to pass as
+// parameters. A literal fragment is frequently just the quote marks
+// wrapping the value in the original, unparameterized SQL (`"...= '" + x +
+// "'"`), so before emitting the placeholder it strips one matching quote
+// character off the end of the preceding literal and the start of the
+// following one - otherwise the `?` lands inside those quotes and binds
+// as the literal string "?" instead of a parameter.
+func parameterize(parts []ast.Expr) (string, []ast.Expr) {
+	type segment struct {
+		lit   string
+		isLit bool
+		expr  ast.Expr
+	}
+	segs := make([]segment, len(parts))
+	for i, p := range parts {
+		if lit, ok := p.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			if s, err := strconv.Unquote(lit.Value); err == nil {
+				segs[i] = segment{lit: s, isLit: true}
+				continue
+			}
+		}
+		segs[i] = segment{expr: p}
+	}
+
+	for i, s := range segs {
+		if s.isLit {
+			continue
+		}
+		if i == 0 || i+1 >= len(segs) || !segs[i-1].isLit || !segs[i+1].isLit {
+			continue
+		}
+		prev, next := segs[i-1].lit, segs[i+1].lit
+		if prev == "" || next == "" {
+			continue
+		}
+		last, first := prev[len(prev)-1], next[0]
+		if (last == '\'' || last == '"') && first == last {
+			segs[i-1].lit = prev[:len(prev)-1]
+			segs[i+1].lit = next[1:]
+		}
+	}
+
+	var sb strings.Builder
+	var params []ast.Expr
+	for _, s := range segs {
+		if s.isLit {
+			sb.WriteString(s.lit)
+			continue
+		}
+		sb.WriteString("?")
+		params = append(params, s.expr)
+	}
+	return sb.String(), params
+}
+
+func callWithSoleArgIdent(stmt ast.Stmt, name string, selectors ...string) (*ast.CallExpr, []ast.Expr, bool) {
+	var call *ast.CallExpr
+	switch s := stmt.(type) {
+	case *ast.ExprStmt:
+		call, _ = s.X.(*ast.CallExpr)
+	case *ast.AssignStmt:
+		if len(s.Rhs) == 1 {
+			call, _ = s.Rhs[0].(*ast.CallExpr)
+		}
+	case *ast.ReturnStmt:
+		if len(s.Results) == 1 {
+			call, _ = s.Results[0].(*ast.CallExpr)
+		}
+	}
+	if call == nil || len(call.Args) != 1 {
+		return nil, nil, false
+	}
+	ident, ok := call.Args[0].(*ast.Ident)
+	if !ok || ident.Name != name {
+		return nil, nil, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, nil, false
+	}
+	for _, want := range selectors {
+		if sel.Sel.Name == want {
+			return call, call.Args, true
+		}
+	}
+	return nil, nil, false
+}
+
+// --- Command injection: exec.Command("sh", "-c", tainted) ---
+
+func (r *rewriter) matchCommandInjection(stmt ast.Stmt, results *ast.FieldList) ([]ast.Stmt, bool) {
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	var call *ast.CallExpr
+	var assign *ast.AssignStmt
+	if ok {
+		call, ok = exprStmt.X.(*ast.CallExpr)
+	}
+	if !ok {
+		assign, ok = stmt.(*ast.AssignStmt)
+		if !ok || len(assign.Rhs) != 1 {
+			return nil, false
+		}
+		call, ok = assign.Rhs[0].(*ast.CallExpr)
+	}
+	if !ok || call == nil {
+		return nil, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Command" || len(call.Args) != 3 {
+		return nil, false
+	}
+	if !isStringLit(call.Args[0], "sh") || !isStringLit(call.Args[1], "-c") {
+		return nil, false
+	}
+	tainted := call.Args[2]
+
+	// Only the non-literal pieces of the shell string are attacker
+	// controlled ("ping " + userInput) - validate those, not the whole
+	// concatenation, or a literal space anywhere in the command (the
+	// common case) makes the allowlist reject every call.
+	var guardTargets []ast.Expr
+	if parts, ok := flattenConcat(tainted); ok {
+		for _, p := range parts {
+			if _, isLit := p.(*ast.BasicLit); !isLit {
+				guardTargets = append(guardTargets, p)
+			}
+		}
+	} else {
+		guardTargets = []ast.Expr{tainted}
+	}
+	if len(guardTargets) == 0 {
+		return nil, false
+	}
+
+	retStmt, ok := buildGuardReturn(results, newErrorsNew("invalid command argument"))
+	if !ok {
+		// Can't safely synthesize a guard clause for this function's
+		// signature - leave the vulnerable call alone rather than emit
+		// code that won't compile.
+		return nil, false
+	}
+
+	r.markChanged(KindCommandInjection)
+	r.requireImport("regexp")
+	r.requireImport("errors")
+	r.requireImport("strings")
+
+	var cond ast.Expr
+	for _, target := range guardTargets {
+		notValid := &ast.UnaryExpr{
+			Op: token.NOT,
+			X: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X: &ast.CallExpr{
+						Fun:  &ast.SelectorExpr{X: ast.NewIdent("regexp"), Sel: ast.NewIdent("MustCompile")},
+						Args: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: "`^[a-zA-Z0-9._-]+$`"}},
+					},
+					Sel: ast.NewIdent("MatchString"),
+				},
+				Args: []ast.Expr{target},
+			},
+		}
+		if cond == nil {
+			cond = notValid
+		} else {
+			cond = &ast.BinaryExpr{X: cond, Op: token.LOR, Y: notValid}
+		}
+	}
+
+	guard := &ast.IfStmt{Cond: cond, Body: &ast.BlockStmt{List: []ast.Stmt{retStmt}}}
+
+	// Split the full command string into a program name and its
+	// arguments at runtime, rather than passing the whole concatenation
+	// as a single (unresolvable) argv[0].
+	splitDecl := &ast.AssignStmt{
+		Lhs: []ast.Expr{ast.NewIdent("cmdParts")},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun:  &ast.SelectorExpr{X: ast.NewIdent("strings"), Sel: ast.NewIdent("Fields")},
+				Args: []ast.Expr{tainted},
+			},
+		},
+	}
+
+	call.Args = []ast.Expr{
+		&ast.IndexExpr{X: ast.NewIdent("cmdParts"), Index: &ast.BasicLit{Kind: token.INT, Value: "0"}},
+		&ast.SliceExpr{X: ast.NewIdent("cmdParts"), Low: &ast.BasicLit{Kind: token.INT, Value: "1"}},
+	}
+	// go/printer compares the line of Ellipsis against the line of
+	// Rparen to decide whether the call spans multiple lines; reusing
+	// the (real) Rparen position keeps it on one line instead of a
+	// synthetic earlier position tricking it into breaking.
+	call.Ellipsis = call.Rparen
+
+	var rewritten ast.Stmt = exprStmt
+	if assign != nil {
+		rewritten = assign
+	}
+	return []ast.Stmt{guard, splitDecl, rewritten}, true
+}
+
+func isStringLit(expr ast.Expr, want string) bool {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return err == nil && s == want
+}
+
+// --- Path traversal: ioutil.ReadFile(baseDir + userVar) ---
+
+func (r *rewriter) matchPathTraversal(first, second ast.Stmt, results *ast.FieldList) ([]ast.Stmt, bool) {
+	assign, ok := first.(*ast.AssignStmt)
+	if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil, false
+	}
+	pathIdent, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+	base, user, ok := splitBaseDirConcat(assign.Rhs[0])
+	if !ok {
+		return nil, false
+	}
+	_, args, ok := callWithSoleArgIdent(second, pathIdent.Name, "ReadFile", "Open")
+	if !ok {
+		return nil, false
+	}
+
+	guard, ok := pathTraversalGuard(base, results)
+	if !ok {
+		// Can't safely synthesize a guard clause for this function's
+		// signature - leave the vulnerable call alone rather than emit
+		// code that won't compile.
+		return nil, false
+	}
+
+	r.markChanged(KindPathTraversal)
+	r.requireImport("path/filepath")
+	r.requireImport("strings")
+	r.requireImport("errors")
+
+	cleanDecl := &ast.AssignStmt{
+		Lhs: []ast.Expr{ast.NewIdent("cleanPath")},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{X: ast.NewIdent("filepath"), Sel: ast.NewIdent("Clean")},
+				Args: []ast.Expr{
+					&ast.CallExpr{
+						Fun:  &ast.SelectorExpr{X: ast.NewIdent("filepath"), Sel: ast.NewIdent("Join")},
+						Args: []ast.Expr{base, user},
+					},
+				},
+			},
+		},
+	}
+	args[0] = ast.NewIdent("cleanPath")
+	// assign ("path := baseDir + filename") is deliberately dropped: base
+	// and user are reused directly in cleanDecl above, and args[0] now
+	// points at cleanPath, so the original path variable has no remaining
+	// reference and would fail to compile ("declared and not used") if
+	// re-emitted.
+	return []ast.Stmt{cleanDecl, guard, second}, true
+}
+
+func splitBaseDirConcat(expr ast.Expr) (ast.Expr, ast.Expr, bool) {
+	bin, ok := expr.(*ast.BinaryExpr)
+	if !ok || bin.Op != token.ADD {
+		return nil, nil, false
+	}
+	return bin.X, bin.Y, true
+}
+
+func pathTraversalGuard(base ast.Expr, results *ast.FieldList) (ast.Stmt, bool) {
+	retStmt, ok := buildGuardReturn(results, newErrorsNew("path traversal attempt detected"))
+	if !ok {
+		return nil, false
+	}
+	return &ast.IfStmt{
+		Cond: &ast.UnaryExpr{
+			Op: token.NOT,
+			X: &ast.CallExpr{
+				Fun:  &ast.SelectorExpr{X: ast.NewIdent("strings"), Sel: ast.NewIdent("HasPrefix")},
+				Args: []ast.Expr{ast.NewIdent("cleanPath"), base},
+			},
+		},
+		Body: &ast.BlockStmt{List: []ast.Stmt{retStmt}},
+	}, true
+}
+
+// --- XSS: fmt.Fprintf(w, "<div>%s</div>", v) ---
+
+func (r *rewriter) matchXSS(stmt ast.Stmt) ([]ast.Stmt, bool) {
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return nil, false
+	}
+	call, ok := exprStmt.X.(*ast.CallExpr)
+	if !ok || len(call.Args) < 3 {
+		return nil, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Fprintf" {
+		return nil, false
+	}
+	format, ok := call.Args[1].(*ast.BasicLit)
+	if !ok || format.Kind != token.STRING {
+		return nil, false
+	}
+	unquoted, err := strconv.Unquote(format.Value)
+	if err != nil || !strings.Contains(unquoted, "%s") || !strings.Contains(unquoted, "<") {
+		return nil, false
+	}
+
+	r.markChanged(KindXSS)
+	r.requireImport("html/template")
+
+	writer := call.Args[0]
+	value := call.Args[2]
+	templateSrc := strings.Replace(unquoted, "%s", "{{.}}", 1)
+
+	tmplDecl := &ast.AssignStmt{
+		Lhs: []ast.Expr{ast.NewIdent("tmpl")},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{X: ast.NewIdent("template"), Sel: ast.NewIdent("Must")},
+				Args: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X: &ast.CallExpr{
+								Fun:  &ast.SelectorExpr{X: ast.NewIdent("template"), Sel: ast.NewIdent("New")},
+								Args: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote("page")}},
+							},
+							Sel: ast.NewIdent("Parse"),
+						},
+						Args: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(templateSrc)}},
+					},
+				},
+			},
+		},
+	}
+	execute := &ast.ExprStmt{X: &ast.CallExpr{
+		Fun:  &ast.SelectorExpr{X: ast.NewIdent("tmpl"), Sel: ast.NewIdent("Execute")},
+		Args: []ast.Expr{writer, value},
+	}}
+
+	return []ast.Stmt{tmplDecl, execute}, true
+}
@@ -0,0 +1,77 @@
+// Package safe provides drop-in wrappers around os/exec, database/sql,
+// ioutil/os, and fmt.Fprintf that enforce the secure patterns documented
+// in security_assistant/remediation/code_examples at the API level:
+// Exec never goes through a shell, Query rejects mismatched placeholder
+// counts, ReadFile refuses to leave its base directory, and RenderHTML
+// only ever accepts an html/template.Template.
+package safe
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// shellBinaries are refused as the direct command name: passing user
+// input as arguments to one of these reintroduces exactly the shell
+// metacharacter injection Exec exists to prevent.
+var shellBinaries = map[string]bool{
+	"sh": true, "bash": true, "zsh": true, "ksh": true,
+	"cmd": true, "cmd.exe": true, "powershell": true, "powershell.exe": true,
+}
+
+// ErrShellInvocation is returned when Exec is asked to run a shell
+// directly.
+var ErrShellInvocation = errors.New("safe: refusing to exec a shell directly")
+
+// Option configures a call to Exec.
+type Option func(*execConfig)
+
+type execConfig struct {
+	allowlist []*regexp.Regexp // allowlist[i] validates args[i]; nil entries mean "no restriction"
+}
+
+// WithAllowlist restricts the argument at the given index to strings
+// matching re. Passing an index beyond len(args) is a no-op.
+func WithAllowlist(index int, re *regexp.Regexp) Option {
+	return func(c *execConfig) {
+		for len(c.allowlist) <= index {
+			c.allowlist = append(c.allowlist, nil)
+		}
+		c.allowlist[index] = re
+	}
+}
+
+// Exec builds an *exec.Cmd for name with args, refusing shell
+// invocations and resolving name via PATH the same way exec.LookPath
+// does post-Go 1.19 (it returns exec.ErrDot rather than silently
+// resolving a binary relative to the current directory - the failure
+// mode the hexec "SafeCommand" pattern exists to close). Any Option
+// applies an allowlist check to the corresponding argument before the
+// command is built.
+func Exec(name string, args []string, opts ...Option) (*exec.Cmd, error) {
+	if shellBinaries[strings.ToLower(name)] {
+		return nil, fmt.Errorf("%w: %q", ErrShellInvocation, name)
+	}
+	if strings.ContainsAny(name, "/\\") {
+		return nil, fmt.Errorf("safe: command name %q must be a bare name resolved via PATH, not a path", name)
+	}
+
+	cfg := &execConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	for i, arg := range args {
+		if i < len(cfg.allowlist) && cfg.allowlist[i] != nil && !cfg.allowlist[i].MatchString(arg) {
+			return nil, fmt.Errorf("safe: argument %d %q does not match allowlist", i, arg)
+		}
+	}
+
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return nil, fmt.Errorf("safe: resolving %q: %w", name, err)
+	}
+	return exec.Command(path, args...), nil
+}
@@ -0,0 +1,38 @@
+package safe
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestExecRejectsShellInvocation(t *testing.T) {
+	_, err := Exec("sh", []string{"-c", "ping 1.1.1.1"})
+	if !errors.Is(err, ErrShellInvocation) {
+		t.Fatalf("Exec(\"sh\", ...): got %v, want ErrShellInvocation", err)
+	}
+}
+
+func TestExecRejectsPathAsName(t *testing.T) {
+	_, err := Exec("/bin/ping", nil)
+	if err == nil {
+		t.Fatal("Exec with a path as name: got nil error, want one")
+	}
+}
+
+func TestExecAppliesAllowlist(t *testing.T) {
+	_, err := Exec("ping", []string{"; rm -rf /"}, WithAllowlist(0, regexp.MustCompile(`^[a-zA-Z0-9.-]+$`)))
+	if err == nil {
+		t.Fatal("Exec with an arg violating the allowlist: got nil error, want one")
+	}
+}
+
+func TestExecAllowsValidCommand(t *testing.T) {
+	cmd, err := Exec("echo", []string{"hello"}, WithAllowlist(0, regexp.MustCompile(`^[a-zA-Z0-9]+$`)))
+	if err != nil {
+		t.Fatalf("Exec: %v", err)
+	}
+	if cmd == nil {
+		t.Fatal("Exec returned a nil *exec.Cmd with a nil error")
+	}
+}
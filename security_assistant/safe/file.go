@@ -0,0 +1,45 @@
+package safe
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PathTraversalError reports that a requested path resolved outside its
+// declared base directory.
+type PathTraversalError struct {
+	BaseDir string
+	Path    string
+}
+
+func (e *PathTraversalError) Error() string {
+	return fmt.Sprintf("safe: path %q escapes base directory %q", e.Path, e.BaseDir)
+}
+
+// ReadFile reads userPath relative to baseDir after cleaning and
+// confirming the result stays within baseDir, encapsulating the
+// filepath.Clean + strings.HasPrefix check from
+// remediation/code_examples/path_traversal_fix.go.txt. It returns a
+// *PathTraversalError, not a plain error, when the check fails, so
+// callers can distinguish traversal attempts from ordinary I/O errors.
+func ReadFile(baseDir, userPath string) ([]byte, error) {
+	clean, err := resolveWithinBase(baseDir, userPath)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(clean)
+}
+
+// resolveWithinBase applies the same Clean+Join+HasPrefix+Base guard
+// used by ReadFile, without performing any I/O; it's split out so other
+// safe/ helpers (or a future safe.Open) can reuse it.
+func resolveWithinBase(baseDir, userPath string) (string, error) {
+	base := filepath.Clean(baseDir)
+	clean := filepath.Clean(filepath.Join(base, filepath.Base(userPath)))
+	if !strings.HasPrefix(clean, base) {
+		return "", &PathTraversalError{BaseDir: base, Path: userPath}
+	}
+	return clean, nil
+}
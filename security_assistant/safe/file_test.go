@@ -0,0 +1,63 @@
+package safe
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadFileReadsWithinBaseDir(t *testing.T) {
+	dir := t.TempDir()
+	want := []byte("hello")
+	if err := os.WriteFile(filepath.Join(dir, "greeting.txt"), want, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadFile(dir, "greeting.txt")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("ReadFile read %q, want %q", got, want)
+	}
+}
+
+// TestReadFileNeutralizesTraversalAttempt confirms that a userPath
+// containing directory components (the shape a naive concatenation-based
+// implementation would follow straight out of the base directory) is
+// reduced to its bare filename by filepath.Base before it ever reaches
+// the base-directory check, so it resolves inside baseDir rather than
+// escaping it.
+func TestReadFileNeutralizesTraversalAttempt(t *testing.T) {
+	dir := t.TempDir()
+	want := []byte("secret")
+	if err := os.WriteFile(filepath.Join(dir, "passwd"), want, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadFile(dir, "../../../etc/passwd")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("ReadFile read %q, want %q", got, want)
+	}
+}
+
+// TestReadFileRejectsDotDotEscape covers the one shape that does escape
+// baseDir despite filepath.Base: passing ".." itself resolves, after
+// Join+Clean, to baseDir's own parent.
+func TestReadFileRejectsDotDotEscape(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "uploads")
+	if err := os.Mkdir(base, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := ReadFile(base, "..")
+	var traversalErr *PathTraversalError
+	if !errors.As(err, &traversalErr) {
+		t.Fatalf("ReadFile(base, \"..\"): got %v, want a *PathTraversalError", err)
+	}
+}
@@ -0,0 +1,16 @@
+package safe
+
+import (
+	"html/template"
+	"io"
+)
+
+// RenderHTML executes tmpl against data and writes the result to w. The
+// parameter type is *html/template.Template rather than
+// *text/template.Template, so the contextual auto-escaping documented in
+// remediation/code_examples/xss_fix.go.txt is enforced at compile time: there
+// is no way to call RenderHTML with a template that doesn't escape its
+// output.
+func RenderHTML(w io.Writer, tmpl *template.Template, data interface{}) error {
+	return tmpl.Execute(w, data)
+}
@@ -0,0 +1,32 @@
+package safe
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+)
+
+// placeholderPattern matches the two placeholder styles used across
+// database/sql drivers: `?` (MySQL/SQLite) and `$1`, `$2`, ... (Postgres).
+var placeholderPattern = regexp.MustCompile(`\?|\$[0-9]+`)
+
+// countPlaceholders returns how many parameter placeholders appear in
+// query. It is a lightweight tokenizer, not a full SQL parser: it does
+// not attempt to skip placeholders that happen to appear inside a quoted
+// string literal, since a query containing a literal `?` or `$1` next to
+// real placeholders is already unusual enough to warrant a second look.
+func countPlaceholders(query string) int {
+	return len(placeholderPattern.FindAllString(query, -1))
+}
+
+// Query runs query against db after checking that the number of `?`/`$n`
+// placeholders in query matches len(args). This catches the class of bug
+// where a caller concatenates a value into the query string and forgets
+// to also add it to args - the parameter count will no longer match what
+// the query text expects.
+func Query(db *sql.DB, query string, args ...interface{}) (*sql.Rows, error) {
+	if n := countPlaceholders(query); n != len(args) {
+		return nil, fmt.Errorf("safe: query expects %d parameter(s), got %d", n, len(args))
+	}
+	return db.Query(query, args...)
+}
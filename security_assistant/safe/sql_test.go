@@ -0,0 +1,81 @@
+package safe
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+)
+
+// fakeDriver is a minimal database/sql driver, registered only so Query
+// can be exercised end-to-end (through a real *sql.DB) without depending
+// on a real database or a vendored driver package.
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("fakeDriver: transactions not supported")
+}
+
+// fakeStmt reports NumInput() == -1, which tells database/sql to skip
+// its own placeholder-count validation - so a mismatch is only ever
+// caught by safe.Query's own check, which is what these tests verify.
+type fakeStmt struct{}
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return -1 }
+func (fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.ResultNoRows, nil
+}
+func (fakeStmt) Query(args []driver.Value) (driver.Rows, error) { return fakeRows{}, nil }
+
+type fakeRows struct{}
+
+func (fakeRows) Columns() []string              { return nil }
+func (fakeRows) Close() error                   { return nil }
+func (fakeRows) Next(dest []driver.Value) error { return io.EOF }
+
+func init() {
+	sql.Register("safe-fake", fakeDriver{})
+}
+
+func TestQueryChecksPlaceholderCount(t *testing.T) {
+	db, err := sql.Open("safe-fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tests := []struct {
+		name    string
+		query   string
+		args    []interface{}
+		wantErr bool
+	}{
+		{"question-mark placeholder matches", "SELECT * FROM users WHERE id = ?", []interface{}{1}, false},
+		{"postgres placeholder matches", "SELECT * FROM users WHERE id = $1", []interface{}{1}, false},
+		{"missing arg", "SELECT * FROM users WHERE id = ?", nil, true},
+		{"extra arg", "SELECT * FROM users", []interface{}{1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := Query(db, tt.query, tt.args...)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Query: got nil error, want one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Query: %v", err)
+			}
+			rows.Close()
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Package vetcheck implements a go-vet-style check: within any package
+// that imports security_assistant/safe, direct use of the unsafe stdlib
+// calls safe/ wraps (os/exec.Command, (*sql.DB).Query/Exec,
+// ioutil.ReadFile/os.Open, fmt.Fprintf) is flagged, since such packages
+// have already opted in to using the wrapper instead.
+package vetcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"security_assistant/internal/typeresolve"
+)
+
+const safeImportPath = "security_assistant/safe"
+
+// Violation is a single direct use of a wrapped stdlib call found in a
+// file that imports security_assistant/safe.
+type Violation struct {
+	File      string
+	Line      int
+	Column    int
+	Call      string
+	Suggested string
+}
+
+// unsafeCall describes a package-level function flagged by selector name,
+// verified (when type information is available) against the package it
+// actually has to come from - so a local variable or unrelated package
+// that happens to share a conventional name like "exec" isn't flagged.
+type unsafeCall struct {
+	pkgPath   string
+	suggested string
+}
+
+// unsafeCalls maps a selector name to the package it must resolve to and
+// the safe/ function that should be used instead.
+var unsafeCalls = map[string]unsafeCall{
+	"Command":  {pkgPath: "os/exec", suggested: "safe.Exec"},
+	"ReadFile": {pkgPath: "io/ioutil", suggested: "safe.ReadFile"},
+	"Open":     {pkgPath: "os", suggested: "safe.ReadFile"},
+	"Fprintf":  {pkgPath: "fmt", suggested: "safe.RenderHTML"},
+}
+
+// dbMethod describes a method flagged regardless of its package
+// qualifier, since the receiver is a *sql.DB/*sql.Tx/*sql.Conn value
+// rather than a package selector. recvTypes is checked against the
+// method's actual receiver (when type information is available) so an
+// unrelated type with a same-named method isn't flagged.
+type dbMethod struct {
+	recvTypes []string
+	suggested string
+}
+
+var dbMethods = map[string]dbMethod{
+	"Query": {recvTypes: []string{"database/sql.DB", "database/sql.Tx", "database/sql.Conn"}, suggested: "safe.Query"},
+	"Exec":  {recvTypes: []string{"database/sql.DB", "database/sql.Tx", "database/sql.Conn"}, suggested: "safe.Query"},
+}
+
+// Check walks path (a file or directory) and returns every Violation
+// found.
+func Check(path string) ([]Violation, error) {
+	var files []string
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		err = filepath.Walk(path, func(p string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !fi.IsDir() && strings.HasSuffix(p, ".go") {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		files = []string{path}
+	}
+
+	fset := token.NewFileSet()
+	resolver := typeresolve.NewResolver(fset)
+	var violations []Violation
+	for _, f := range files {
+		src, err := parser.ParseFile(fset, f, nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("vetcheck: parsing %s: %w", f, err)
+		}
+		if !importsSafe(src) {
+			continue
+		}
+		typeInfo := resolver.Check(fset, src)
+		violations = append(violations, checkFile(fset, f, src, typeInfo)...)
+	}
+	return violations, nil
+}
+
+func importsSafe(src *ast.File) bool {
+	for _, imp := range src.Imports {
+		if strings.Trim(imp.Path.Value, `"`) == safeImportPath {
+			return true
+		}
+	}
+	return false
+}
+
+func checkFile(fset *token.FileSet, filename string, src *ast.File, typeInfo *typeresolve.Info) []Violation {
+	var violations []Violation
+	ast.Inspect(src, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		var suggested string
+		var matched bool
+		if uc, ok := unsafeCalls[sel.Sel.Name]; ok {
+			if path, resolved := typeInfo.PackageQualifier(sel); !resolved || path == uc.pkgPath {
+				suggested, matched = uc.suggested, true
+			}
+		}
+		if !matched {
+			if dm, ok := dbMethods[sel.Sel.Name]; ok {
+				if recv, resolved := typeInfo.ReceiverType(sel); !resolved {
+					suggested, matched = dm.suggested, true
+				} else {
+					for _, want := range dm.recvTypes {
+						if recv == want {
+							suggested, matched = dm.suggested, true
+							break
+						}
+					}
+				}
+			}
+		}
+		if !matched {
+			return true
+		}
+
+		pos := fset.Position(call.Pos())
+		violations = append(violations, Violation{
+			File:      filename,
+			Line:      pos.Line,
+			Column:    pos.Column,
+			Call:      exprString(sel),
+			Suggested: suggested,
+		})
+		return true
+	})
+	return violations
+}
+
+func exprString(sel *ast.SelectorExpr) string {
+	if pkg, ok := sel.X.(*ast.Ident); ok {
+		return pkg.Name + "." + sel.Sel.Name
+	}
+	return sel.Sel.Name
+}
@@ -0,0 +1,92 @@
+package vetcheck
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, src string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCheckFlagsDirectUnsafeCallInSafeImportingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "handler.go", `package handler
+
+import (
+	"os/exec"
+
+	_ "security_assistant/safe"
+)
+
+func run(host string) {
+	exec.Command("ping", host)
+}
+`)
+
+	violations, err := Check(path)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if len(violations) != 1 {
+		t.Fatalf("got %d violations, want 1: %+v", len(violations), violations)
+	}
+	if violations[0].Suggested != "safe.Exec" {
+		t.Errorf("got Suggested %q, want %q", violations[0].Suggested, "safe.Exec")
+	}
+}
+
+func TestCheckIgnoresFileNotImportingSafe(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "handler.go", `package handler
+
+import "os/exec"
+
+func run(host string) {
+	exec.Command("ping", host)
+}
+`)
+
+	violations, err := Check(path)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("got %d violations for a file that doesn't import safe/, want 0: %+v", len(violations), violations)
+	}
+}
+
+// TestCheckIgnoresUnrelatedReceiverType is the go/types regression case:
+// a user-defined type with its own same-named Query method must not be
+// flagged just because the selector name matches database/sql's.
+func TestCheckIgnoresUnrelatedReceiverType(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "handler.go", `package handler
+
+import (
+	_ "security_assistant/safe"
+)
+
+type cache struct{}
+
+func (c cache) Query(key string) string { return key }
+
+func run(c cache, key string) string {
+	return c.Query(key)
+}
+`)
+
+	violations, err := Check(path)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("got %d violations for an unrelated Query method, want 0: %+v", len(violations), violations)
+	}
+}